@@ -0,0 +1,152 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/jirasdk/core/issue"
+)
+
+// commentADF converts a plain-text comment to an ADF document. Blank lines separate
+// paragraphs, other line breaks become hard breaks, and consecutive lines starting with
+// "- " or "* " form a bullet list. With the adf format, links and **bold** markup in the
+// text are formatted as well (see adfInline).
+func commentADF(text, format string) *issue.ADF {
+	doc := &issue.ADF{Version: 1, Type: "doc"}
+	rich := format == commentFormatADF
+
+	var lines, items []string
+	flush := func() {
+		if len(lines) > 0 {
+			doc.Content = append(doc.Content, adfParagraph(lines, rich))
+			lines = nil
+		}
+		if len(items) > 0 {
+			list := issue.ADFNode{Type: "bulletList"}
+			for _, item := range items {
+				list.Content = append(list.Content, issue.ADFNode{
+					Type:    "listItem",
+					Content: []issue.ADFNode{adfParagraph([]string{item}, rich)},
+				})
+			}
+			doc.Content = append(doc.Content, list)
+			items = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			if len(lines) > 0 {
+				flush()
+			}
+			items = append(items, strings.TrimSpace(trimmed[2:]))
+		default:
+			if len(items) > 0 {
+				flush()
+			}
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	// Jira rejects documents without content
+	if len(doc.Content) == 0 {
+		doc.Content = []issue.ADFNode{{Type: "paragraph"}}
+	}
+	return doc
+}
+
+// adfParagraph returns a paragraph holding lines separated by hard breaks. Rich lines are
+// parsed for inline formatting.
+func adfParagraph(lines []string, rich bool) issue.ADFNode {
+	paragraph := issue.ADFNode{Type: "paragraph"}
+	for i, line := range lines {
+		if i > 0 {
+			paragraph.Content = append(paragraph.Content, issue.ADFNode{Type: "hardBreak"})
+		}
+		if rich {
+			paragraph.Content = append(paragraph.Content, adfInline(line)...)
+			continue
+		}
+		paragraph.Content = append(paragraph.Content, issue.ADFNode{Type: "text", Text: line})
+	}
+	return paragraph
+}
+
+// adfInlinePattern matches the inline markup of rich comments: markdown links, **bold**
+// text and bare http(s) URLs.
+var adfInlinePattern = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^\s)]+)\)|\*\*([^*]+)\*\*|https?://[^\s<>()]+`)
+
+// adfInline converts a line to text nodes, turning [text](url) and bare URLs (such as the
+// rendered {release_url}) into links and **text** into bold text.
+func adfInline(line string) []issue.ADFNode {
+	var nodes []issue.ADFNode
+	text := func(s string, marks ...issue.ADFMark) {
+		if s != "" {
+			nodes = append(nodes, issue.ADFNode{Type: "text", Text: s, Marks: marks})
+		}
+	}
+	link := func(href string) issue.ADFMark {
+		return issue.ADFMark{Type: "link", Attrs: map[string]interface{}{"href": href}}
+	}
+
+	last := 0
+	for _, m := range adfInlinePattern.FindAllStringSubmatchIndex(line, -1) {
+		text(line[last:m[0]])
+		last = m[1]
+		switch {
+		case m[2] >= 0:
+			text(line[m[2]:m[3]], link(line[m[4]:m[5]]))
+		case m[6] >= 0:
+			text(line[m[6]:m[7]], issue.ADFMark{Type: "strong"})
+		default:
+			// Punctuation ending a sentence is not part of the URL
+			href := strings.TrimRight(line[m[0]:m[1]], ".,;:!?'\"")
+			text(href, link(href))
+			last = m[0] + len(href)
+		}
+	}
+	text(line[last:])
+	return nodes
+}
+
+// adfText renders an ADF document back to the plain-text form accepted by commentADF.
+// Formatting marks are dropped.
+func adfText(doc *issue.ADF) string {
+	if doc == nil {
+		return ""
+	}
+	var inline func(nodes []issue.ADFNode) string
+	inline = func(nodes []issue.ADFNode) string {
+		var b strings.Builder
+		for _, node := range nodes {
+			switch node.Type {
+			case "text":
+				b.WriteString(node.Text)
+			case "hardBreak":
+				b.WriteString("\n")
+			default:
+				b.WriteString(inline(node.Content))
+			}
+		}
+		return b.String()
+	}
+
+	blocks := make([]string, 0, len(doc.Content))
+	for _, block := range doc.Content {
+		if block.Type != "bulletList" {
+			blocks = append(blocks, inline(block.Content))
+			continue
+		}
+		items := make([]string, 0, len(block.Content))
+		for _, item := range block.Content {
+			items = append(items, "- "+inline(item.Content))
+		}
+		blocks = append(blocks, strings.Join(items, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommentADF(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "single_line",
+			text: "Released in 1.0.0",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Released in 1.0.0"}]}]`,
+		},
+		{
+			name: "paragraphs_and_line_breaks",
+			text: "Released in 1.0.0\nSee the notes.\n\nThanks",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Released in 1.0.0"},{"type":"hardBreak"},{"type":"text","text":"See the notes."}]},` +
+				`{"type":"paragraph","content":[{"type":"text","text":"Thanks"}]}]`,
+		},
+		{
+			name: "changelog_with_bullets",
+			text: "Changes in 1.0.0:\n- add export\n* fix crash\n\nDone",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Changes in 1.0.0:"}]},` +
+				`{"type":"bulletList","content":[` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"add export"}]}]},` +
+				`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"fix crash"}]}]}]},` +
+				`{"type":"paragraph","content":[{"type":"text","text":"Done"}]}]`,
+		},
+		{
+			name: "text_after_list",
+			text: "- one\nafter",
+			want: `[{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"one"}]}]}]},` +
+				`{"type":"paragraph","content":[{"type":"text","text":"after"}]}]`,
+		},
+		{
+			name: "empty",
+			text: "",
+			want: `[{"type":"paragraph"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := commentADF(tt.text, commentFormatText)
+			if doc.Version != 1 || doc.Type != "doc" {
+				t.Errorf("unexpected document header: version %d, type %q", doc.Version, doc.Type)
+			}
+			got, err := json.Marshal(doc.Content)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected ADF\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentADFRich(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "bare_url",
+			text: "Released in 1.0.0: https://github.com/org/repo/releases/v1.0.0.",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Released in 1.0.0: "},` +
+				`{"type":"text","text":"https://github.com/org/repo/releases/v1.0.0","marks":[{"type":"link","attrs":{"href":"https://github.com/org/repo/releases/v1.0.0"}}]},` +
+				`{"type":"text","text":"."}]}]`,
+		},
+		{
+			name: "markdown_link_and_bold",
+			text: "**Released** in [1.0.0](https://example.com/r)",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Released","marks":[{"type":"strong"}]},` +
+				`{"type":"text","text":" in "},` +
+				`{"type":"text","text":"1.0.0","marks":[{"type":"link","attrs":{"href":"https://example.com/r"}}]}]}]`,
+		},
+		{
+			name: "bullets",
+			text: "- **fix** crash",
+			want: `[{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[` +
+				`{"type":"text","text":"fix","marks":[{"type":"strong"}]},{"type":"text","text":" crash"}]}]}]}]`,
+		},
+		{
+			name: "plain",
+			text: "Released in 1.0.0",
+			want: `[{"type":"paragraph","content":[{"type":"text","text":"Released in 1.0.0"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(commentADF(tt.text, commentFormatADF).Content)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected ADF\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("text_format_keeps_markup", func(t *testing.T) {
+		text := "**Released** in https://example.com/r"
+		if got := adfText(commentADF(text, commentFormatText)); got != text {
+			t.Errorf("expected %q unchanged, got %q", text, got)
+		}
+	})
+}
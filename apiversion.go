@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/felixgeelhaar/jirasdk/core/issue"
+	"github.com/felixgeelhaar/jirasdk/transport"
+)
+
+// Jira REST API versions selected with api_version.
+const (
+	apiVersionAuto = "auto"
+	apiVersion2    = "2"
+	apiVersion3    = "3"
+)
+
+// apiPrefix is the prefix of the v3 REST API paths that the SDK and the plugin build requests with.
+const apiPrefix = "/rest/api/3/"
+
+// serverInfoPath is probed to detect whether the server offers the v3 REST API.
+const serverInfoPath = apiPrefix + "serverInfo"
+
+// apiPath returns the path of a v3 REST API endpoint in the given API version. Paths outside
+// the v3 REST API are returned unchanged.
+func apiPath(version, path string) string {
+	if version != apiVersion2 || !strings.HasPrefix(path, apiPrefix) {
+		return path
+	}
+	return "/rest/api/2/" + strings.TrimPrefix(path, apiPrefix)
+}
+
+// searchJQLPath is the token-paged JQL search endpoint, which only Jira Cloud offers. On v2 it
+// is served by searchPathV2, which pages with startAt instead.
+const (
+	searchJQLPath = apiPrefix + "search/jql"
+	searchPathV2  = "/rest/api/2/search"
+)
+
+// commentPathPattern matches the v2 comment endpoints, which take and return comment bodies as
+// wiki markup text where v3 uses ADF.
+var commentPathPattern = regexp.MustCompile(`^/rest/api/2/issue/[^/]+/comment(/[^/]+)?$`)
+
+// apiVersionSelector sends REST API requests to the configured API version. Without one, it
+// probes serverInfoPath on the first request and falls back to v2 if v3 is missing, as on
+// Jira Server and Data Center deployments that only expose /rest/api/2.
+type apiVersionSelector struct {
+	mu      sync.Mutex
+	version string
+	logger  *slog.Logger
+}
+
+// newAPIVersionSelector returns a selector for the configured api_version.
+func newAPIVersionSelector(cfg *Config, logger *slog.Logger) *apiVersionSelector {
+	s := &apiVersionSelector{logger: logger}
+	if cfg.APIVersion != apiVersionAuto {
+		s.version = cfg.APIVersion
+	}
+	return s
+}
+
+// resolve returns the API version, probing the server through next on first use. Only a JSON
+// answer shows the server offers v3 and only a 404 that it lacks it. Any other answer is not
+// remembered, so the next request probes again: a successful non-JSON answer, such as a login
+// page, fails with errNotJiraEndpoint, and an error status is returned as the response to req
+// so that the outer middlewares report or retry it like any other.
+func (s *apiVersionSelector) resolve(ctx context.Context, next transport.RoundTripFunc, req *http.Request) (string, *http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version != "" {
+		return s.version, nil, nil
+	}
+
+	probeURL := *req.URL
+	probeURL.Path, probeURL.RawPath, probeURL.RawQuery = serverInfoPath, "", ""
+	probe, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), nil)
+	if err != nil {
+		return "", nil, err
+	}
+	probe.Header.Set("Accept", "application/json")
+	resp, err := next(ctx, probe)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to detect the Jira REST API version: %w", err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		s.version = apiVersion2
+	case resp.StatusCode >= 200 && resp.StatusCode < 300 && isJSONContentType(contentType):
+		s.version = apiVersion3
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		_ = resp.Body.Close()
+		return "", nil, fmt.Errorf("failed to detect the Jira REST API version: %w (got %q response with HTTP %d from %s)",
+			errNotJiraEndpoint, contentType, resp.StatusCode, serverInfoPath)
+	default:
+		return "", resp, nil
+	}
+	_ = resp.Body.Close()
+	s.logger.Debug("detected Jira REST API version", "api_version", s.version)
+	return s.version, nil, nil
+}
+
+// middleware rewrites REST API paths to the selected version. On v2, comment bodies are
+// converted between the ADF the SDK works with and the text the v2 API expects, and JQL
+// searches are sent to the startAt-paged search endpoint.
+func (s *apiVersionSelector) middleware() transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, "/rest/api/") {
+				return next(ctx, req)
+			}
+			version, answer, err := s.resolve(ctx, next, req)
+			if err != nil || answer != nil {
+				return answer, err
+			}
+			if version != apiVersion2 {
+				return next(ctx, req)
+			}
+
+			if req.Method == http.MethodPost && req.URL.Path == searchJQLPath {
+				return searchV2(ctx, next, req)
+			}
+
+			// The request is updated in place so that outer middlewares, such as the logging
+			// and the retries, see the path actually requested
+			req.URL.Path = apiPath(version, req.URL.Path)
+			if req.URL.RawPath != "" {
+				req.URL.RawPath = apiPath(version, req.URL.RawPath)
+			}
+			if !commentPathPattern.MatchString(req.URL.Path) {
+				return next(ctx, req)
+			}
+
+			if err := textCommentRequest(req); err != nil {
+				return nil, err
+			}
+			resp, err := next(ctx, req)
+			if err != nil || resp.StatusCode >= 300 {
+				return resp, err
+			}
+			return adfCommentResponse(resp)
+		}
+	}
+}
+
+// searchV2 sends a token-paged JQL search to the v2 search endpoint. The page token is sent as
+// startAt, and the token of the next page is derived from the response's startAt and total.
+func searchV2(ctx context.Context, next transport.RoundTripFunc, req *http.Request) (*http.Response, error) {
+	req.URL.Path, req.URL.RawPath = searchPathV2, ""
+
+	var input map[string]json.RawMessage
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, fmt.Errorf("invalid search request: %w", err)
+		}
+	}
+	if input == nil {
+		input = map[string]json.RawMessage{}
+	}
+	var token string
+	if raw, ok := input["nextPageToken"]; ok {
+		if err := json.Unmarshal(raw, &token); err != nil {
+			return nil, fmt.Errorf("invalid search page token: %w", err)
+		}
+		delete(input, "nextPageToken")
+	}
+	startAt := 0
+	if token != "" {
+		var err error
+		if startAt, err = strconv.Atoi(token); err != nil {
+			return nil, fmt.Errorf("invalid search page token %q", token)
+		}
+	}
+	input["startAt"], _ = json.Marshal(startAt)
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	resp, err := next(ctx, req)
+	if err != nil || resp.StatusCode >= 300 {
+		return resp, err
+	}
+	data, err = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var page struct {
+		StartAt int               `json:"startAt"`
+		Total   int               `json:"total"`
+		Issues  []json.RawMessage `json:"issues"`
+	}
+	var payload map[string]json.RawMessage
+	if json.Unmarshal(data, &page) == nil && json.Unmarshal(data, &payload) == nil {
+		if end := page.StartAt + len(page.Issues); len(page.Issues) > 0 && end < page.Total {
+			payload["nextPageToken"], _ = json.Marshal(strconv.Itoa(end))
+		}
+		if converted, err := json.Marshal(payload); err == nil {
+			data = converted
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// textCommentRequest replaces the ADF body of a comment request with its plain text.
+// Requests whose body is already text are left unchanged.
+func textCommentRequest(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	var input map[string]json.RawMessage
+	var doc issue.ADF
+	if json.Unmarshal(data, &input) == nil && json.Unmarshal(input["body"], &doc) == nil && doc.Type == "doc" {
+		input["body"], _ = json.Marshal(adfText(&doc))
+		if data, err = json.Marshal(input); err != nil {
+			return err
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil
+}
+
+// adfCommentResponse converts the text comment bodies of a v2 response, either a single
+// comment or a page of comments, to ADF.
+func adfCommentResponse(resp *http.Response) (*http.Response, error) {
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var payload map[string]json.RawMessage
+	if json.Unmarshal(data, &payload) == nil {
+		if raw, ok := payload["comments"]; ok {
+			var comments []map[string]json.RawMessage
+			if json.Unmarshal(raw, &comments) == nil {
+				for _, comment := range comments {
+					adfCommentBody(comment)
+				}
+				payload["comments"], _ = json.Marshal(comments)
+			}
+		} else {
+			adfCommentBody(payload)
+		}
+		if converted, err := json.Marshal(payload); err == nil {
+			data = converted
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// adfCommentBody replaces a comment's text body with the equivalent ADF document.
+func adfCommentBody(comment map[string]json.RawMessage) {
+	var text string
+	if json.Unmarshal(comment["body"], &text) == nil {
+		comment["body"], _ = json.Marshal(commentADF(text, commentFormatText))
+	}
+}
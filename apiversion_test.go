@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/felixgeelhaar/jirasdk/core/issue"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// TestAPIPath tests the mapping of v3 REST API paths to other API versions.
+func TestAPIPath(t *testing.T) {
+	tests := []struct {
+		version  string
+		path     string
+		expected string
+	}{
+		{apiVersion3, "/rest/api/3/issue/PROJ-1/comment", "/rest/api/3/issue/PROJ-1/comment"},
+		{apiVersion2, "/rest/api/3/issue/PROJ-1/comment", "/rest/api/2/issue/PROJ-1/comment"},
+		{apiVersion2, "/rest/api/3/project/PROJ/version", "/rest/api/2/project/PROJ/version"},
+		{apiVersion2, "/rest/api/2/version", "/rest/api/2/version"},
+		{apiVersion2, "/rest/agile/1.0/board", "/rest/agile/1.0/board"},
+	}
+	for _, tt := range tests {
+		if got := apiPath(tt.version, tt.path); got != tt.expected {
+			t.Errorf("apiPath(%q, %q) = %q, want %q", tt.version, tt.path, got, tt.expected)
+		}
+	}
+}
+
+// TestAPIVersionSelectorResolve tests that detection settles on v3 only for a JSON answer and
+// on v2 only for a 404, and probes again after any other answer.
+func TestAPIVersionSelectorResolve(t *testing.T) {
+	answer := func(status int, contentType string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{contentType}},
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	}
+	tests := []struct {
+		name     string
+		answers  []*http.Response
+		expected string
+	}{
+		{"json", []*http.Response{answer(http.StatusOK, "application/json;charset=UTF-8")}, apiVersion3},
+		{"missing", []*http.Response{answer(http.StatusNotFound, "text/html")}, apiVersion2},
+		{"login page", []*http.Response{answer(http.StatusOK, "text/html"), answer(http.StatusOK, "application/json")}, apiVersion3},
+		{"server error", []*http.Response{answer(http.StatusServiceUnavailable, "application/json"), answer(http.StatusNotFound, "application/json")}, apiVersion2},
+		{"unauthorized", []*http.Response{answer(http.StatusUnauthorized, "application/json"), answer(http.StatusOK, "application/json")}, apiVersion3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probes := 0
+			next := func(_ context.Context, _ *http.Request) (*http.Response, error) {
+				resp := tt.answers[probes]
+				probes++
+				return resp, nil
+			}
+			req := httptest.NewRequest(http.MethodGet, "https://example.atlassian.net/rest/api/3/myself", nil)
+			s := &apiVersionSelector{logger: slog.New(slog.DiscardHandler)}
+
+			for range len(tt.answers) - 1 {
+				if version, answer, err := s.resolve(context.Background(), next, req); err == nil && answer == nil {
+					t.Fatalf("expected detection to fail, got %q", version)
+				}
+				if s.version != "" {
+					t.Fatalf("expected no version after a failed probe, got %q", s.version)
+				}
+			}
+			version, answer, err := s.resolve(context.Background(), next, req)
+			if err != nil || answer != nil {
+				t.Fatalf("unexpected error: %v (status %v)", err, answer)
+			}
+			if version != tt.expected || probes != len(tt.answers) {
+				t.Errorf("expected %s after %d probes, got %s after %d", tt.expected, len(tt.answers), version, probes)
+			}
+		})
+	}
+}
+
+// newMockJiraServer returns a mock Jira Server that only exposes the v2 REST API, taking and
+// returning comment bodies as text, and the requests it received.
+func newMockJiraServer(t *testing.T) (*mockJira, *httptest.Server, func() []string) {
+	t.Helper()
+	mock, _ := newMockJira(t)
+
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		rest, ok := strings.CutPrefix(r.URL.Path, "/rest/api/2/")
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{"not found: " + r.URL.Path}})
+			return
+		}
+		r.URL.Path = apiPrefix + rest
+		if !strings.HasSuffix(rest, "/comment") {
+			mock.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			var input struct {
+				Body string `json:"body"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{"comment body must be a string"}})
+				return
+			}
+			data, _ := json.Marshal(issue.AddCommentInput{Body: commentADF(input.Body, commentFormatText)})
+			r.Body = io.NopCloser(bytes.NewReader(data))
+		}
+		recorder := httptest.NewRecorder()
+		mock.ServeHTTP(recorder, r)
+
+		// Comment listings carry text bodies on v2
+		var page struct {
+			Comments []struct {
+				ID   string     `json:"id"`
+				Body *issue.ADF `json:"body"`
+			} `json:"comments"`
+		}
+		body := recorder.Body.Bytes()
+		if r.Method == http.MethodGet && json.Unmarshal(body, &page) == nil {
+			comments := []map[string]any{}
+			for _, comment := range page.Comments {
+				comments = append(comments, map[string]any{"id": comment.ID, "body": adfText(comment.Body)})
+			}
+			body, _ = json.Marshal(map[string]any{"comments": comments, "total": len(comments)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(recorder.Code)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return mock, server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+// TestHandlePostPublishAPIVersion tests that releases run against the v2 REST API when it is
+// configured or detected, and stay on v3 when the server offers it.
+func TestHandlePostPublishAPIVersion(t *testing.T) {
+	run := func(t *testing.T, baseURL string, config map[string]any) {
+		t.Helper()
+		cfg := map[string]any{
+			"base_url":         baseURL,
+			"project_key":      "PROJ",
+			"username":         "user@example.com",
+			"token":            "token",
+			"add_comment":      true,
+			"comment_template": "Released in {version}\nSee the release notes",
+		}
+		for name, value := range config {
+			cfg[name] = value
+		}
+		p := &JiraPlugin{}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:   plugin.HookPostPublish,
+			Config: cfg,
+			Context: plugin.ReleaseContext{
+				Version: "v1.0.0",
+				Changes: &plugin.CategorizedChanges{
+					Fixes: []plugin.ConventionalCommit{{Description: "fix PROJ-1"}, {Description: "fix PROJ-2"}},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		config     map[string]any
+		wantProbes int
+	}{
+		{name: "detected", wantProbes: 1},
+		{name: "configured", config: map[string]any{"api_version": 2}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, server, requests := newMockJiraServer(t)
+			// An earlier attempt already commented on PROJ-2
+			mock.comments["PROJ-2"] = []string{"Released in v1.0.0\nSee the release notes"}
+
+			run(t, server.URL, tt.config)
+
+			var probes int
+			for _, request := range requests() {
+				switch {
+				case request == "GET "+serverInfoPath:
+					probes++
+				case strings.Contains(request, apiPrefix):
+					t.Errorf("expected only v2 requests after detection, got %s", request)
+				}
+			}
+			if probes != tt.wantProbes {
+				t.Errorf("expected %d serverInfo probes, got %d", tt.wantProbes, probes)
+			}
+			if !containsString(requests(), "POST /rest/api/2/version") {
+				t.Errorf("expected the version to be created on v2, got %v", requests())
+			}
+			if got := mock.comments["PROJ-1"]; len(got) != 1 || got[0] != "Released in v1.0.0\nSee the release notes" {
+				t.Errorf("expected the text comment on PROJ-1, got %q", got)
+			}
+			if got := mock.comments["PROJ-2"]; len(got) != 1 {
+				t.Errorf("expected the existing comment on PROJ-2 to be recognized, got %q", got)
+			}
+		})
+	}
+
+	t.Run("cloud", func(t *testing.T) {
+		mock, server := newMockJira(t)
+
+		run(t, server.URL, nil)
+
+		if n := mock.requestCount(http.MethodGet, serverInfoPath); n != 1 {
+			t.Errorf("expected one serverInfo probe, got %d", n)
+		}
+		if n := mock.requestCount(http.MethodPost, "/rest/api/3/version"); n != 1 {
+			t.Errorf("expected the version to be created on v3, got %d", n)
+		}
+	})
+}
+
+// TestHandlePostPublishJQLIssueSourceV2 tests that JQL searches page with startAt on the v2
+// REST API, which has no token-paged search endpoint.
+func TestHandlePostPublishJQLIssueSourceV2(t *testing.T) {
+	mock, server, requests := newMockJiraServer(t)
+	var starts []any
+	mock.override = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method != http.MethodPost || r.URL.Path != apiPrefix+"search" {
+			return false
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mock.mu.Lock()
+		starts = append(starts, body["startAt"])
+		mock.mu.Unlock()
+		if _, ok := body["nextPageToken"]; ok {
+			t.Errorf("expected no nextPageToken on v2, got %v", body)
+		}
+
+		page := map[string]any{"startAt": body["startAt"], "maxResults": 2, "total": 3}
+		if body["startAt"] == float64(0) {
+			page["issues"] = []map[string]any{{"id": "1", "key": "PROJ-1"}, {"id": "2", "key": "PROJ-2"}}
+		} else {
+			page["issues"] = []map[string]any{{"id": "3", "key": "PROJ-3"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+		return true
+	}
+
+	p := &JiraPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"base_url":        server.URL,
+			"project_key":     "PROJ",
+			"username":        "user@example.com",
+			"token":           "token",
+			"api_version":     "2",
+			"release_version": false,
+			"issue_source":    "jql",
+			"jql_query":       "project = {project}",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(starts) != 2 || starts[0] != float64(0) || starts[1] != float64(2) {
+		t.Errorf("expected pages starting at 0 and 2, got %v", starts)
+	}
+	if issues, _ := resp.Outputs["issues"].([]string); strings.Join(issues, ",") != "PROJ-1,PROJ-2,PROJ-3" {
+		t.Errorf("expected issues PROJ-1,PROJ-2,PROJ-3, got %v", resp.Outputs["issues"])
+	}
+	if containsString(requests(), "POST /rest/api/2/search/jql") {
+		t.Errorf("expected no requests to the Cloud-only search endpoint, got %v", requests())
+	}
+}
+
+// TestValidateAPIVersion tests api_version validation.
+func TestValidateAPIVersion(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "token")
+	t.Setenv("JIRA_USERNAME", "user@example.com")
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{name: "auto", config: map[string]any{"api_version": "auto"}},
+		{name: "integer", config: map[string]any{"api_version": 2}},
+		{name: "string", config: map[string]any{"api_version": "3", "comment_format": "adf"}},
+		{name: "unknown version", config: map[string]any{"api_version": 4}, wantField: "api_version"},
+		{name: "adf on v2", config: map[string]any{"api_version": "2", "comment_format": "adf"}, wantField: "comment_format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"base_url": "https://company.atlassian.net", "project_key": "PROJ"}
+			for name, value := range tt.config {
+				config[name] = value
+			}
+
+			p := &JiraPlugin{}
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got %+v", resp.Errors)
+				}
+				return
+			}
+			if resp.Valid || len(resp.Errors) != 1 || resp.Errors[0].Field != tt.wantField {
+				t.Errorf("expected an error for %s, got %+v", tt.wantField, resp.Errors)
+			}
+		})
+	}
+}
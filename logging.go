@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/felixgeelhaar/jirasdk/transport"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// logOutput receives the plugin's log entries. The plugin host collects what plugins write
+// to stderr. It is a variable so tests can inspect the entries.
+var logOutput io.Writer = os.Stderr
+
+// Levels accepted by log_level.
+const (
+	logLevelDebug = "debug"
+	logLevelInfo  = "info"
+	logLevelWarn  = "warn"
+	logLevelError = "error"
+	logLevelOff   = "off"
+)
+
+// logger returns a logger writing entries at the configured log_level or above to logOutput.
+// An empty level means info.
+func (c *Config) logger() *slog.Logger {
+	var level slog.Level
+	switch c.LogLevel {
+	case logLevelDebug:
+		level = slog.LevelDebug
+	case logLevelWarn:
+		level = slog.LevelWarn
+	case logLevelError:
+		level = slog.LevelError
+	case logLevelOff:
+		return slog.New(slog.DiscardHandler)
+	default:
+		level = slog.LevelInfo
+	}
+	return slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: level})).With("plugin", "jira")
+}
+
+// logResult logs the outcome of a hook at info level, or error level when it failed, and
+// returns resp unchanged. resp should already be redacted.
+func (c *Config) logResult(hook plugin.Hook, resp *plugin.ExecuteResponse) *plugin.ExecuteResponse {
+	if resp == nil {
+		return resp
+	}
+	logger := c.logger()
+	if resp.Success {
+		logger.Info("hook finished", "hook", string(hook), "message", resp.Message)
+	} else {
+		logger.Error("hook failed", "hook", string(hook), "error", resp.Error)
+	}
+	return resp
+}
+
+// loggingMiddleware logs each Jira API call at debug level with its method, endpoint, status
+// and duration. Only the URL path is logged: the host (see redact_base_url), query string,
+// headers (including the credentials) and bodies are left out.
+func loggingMiddleware(logger *slog.Logger) transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			attrs := []any{"method", req.Method, "endpoint", req.URL.Path, "duration", time.Since(start).Round(time.Millisecond)}
+			if err != nil {
+				logger.Debug("jira request failed", append(attrs, "error", err.Error())...)
+			} else if resp != nil {
+				logger.Debug("jira request", append(attrs, "status", resp.StatusCode)...)
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// logBuffer collects log output safely across goroutines.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// captureLogs redirects the plugin's log output for the duration of a test.
+func captureLogs(t *testing.T) *logBuffer {
+	t.Helper()
+	buf := &logBuffer{}
+	orig := logOutput
+	logOutput = buf
+	t.Cleanup(func() { logOutput = orig })
+	return buf
+}
+
+func TestExecuteLogging(t *testing.T) {
+	run := func(t *testing.T, level string) (string, *plugin.ExecuteResponse) {
+		t.Helper()
+		logs := captureLogs(t)
+		_, server := newMockJira(t)
+
+		p := &JiraPlugin{}
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook: plugin.HookPostPublish,
+			Config: map[string]any{
+				"base_url":          server.URL,
+				"project_key":       "PROJ",
+				"username":          "user@example.com",
+				"token":             "secret-token",
+				"release_version":   false,
+				"transition_issues": false,
+				"log_level":         level,
+			},
+			Context: plugin.ReleaseContext{
+				Version: "1.0.0",
+				Changes: &plugin.CategorizedChanges{
+					Fixes: []plugin.ConventionalCommit{{Description: "fix PROJ-1"}},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		return logs.String(), resp
+	}
+
+	t.Run("debug logs each API call", func(t *testing.T) {
+		logs, _ := run(t, "debug")
+
+		for _, want := range []string{
+			"msg=\"jira request\" plugin=jira method=GET endpoint=/rest/api/3/project/PROJ/version",
+			"method=POST endpoint=/rest/api/3/version",
+			"method=PUT endpoint=/rest/api/3/issue/PROJ-1",
+			"status=200",
+			"msg=\"extracted issue keys\"",
+			"level=INFO msg=\"hook finished\" plugin=jira hook=post-publish",
+		} {
+			if !strings.Contains(logs, want) {
+				t.Errorf("expected logs to contain %q, got:\n%s", want, logs)
+			}
+		}
+		if strings.Contains(logs, "secret-token") || strings.Contains(logs, "127.0.0.1") {
+			t.Errorf("expected the token and host to be left out, got:\n%s", logs)
+		}
+	})
+
+	t.Run("info logs summaries only", func(t *testing.T) {
+		logs, _ := run(t, "")
+
+		if !strings.Contains(logs, "msg=\"publishing release\"") || !strings.Contains(logs, "msg=\"hook finished\"") {
+			t.Errorf("expected summaries, got:\n%s", logs)
+		}
+		if strings.Contains(logs, "level=DEBUG") {
+			t.Errorf("expected no debug entries, got:\n%s", logs)
+		}
+	})
+
+	t.Run("off", func(t *testing.T) {
+		if logs, _ := run(t, "off"); logs != "" {
+			t.Errorf("expected no logs, got:\n%s", logs)
+		}
+	})
+
+	t.Run("response unchanged", func(t *testing.T) {
+		_, quiet := run(t, "off")
+		_, verbose := run(t, "debug")
+		// Version URLs embed the per-test server address
+		delete(quiet.Outputs, "version_url")
+		delete(verbose.Outputs, "version_url")
+		if quiet.Message != verbose.Message || !reflect.DeepEqual(quiet.Outputs, verbose.Outputs) {
+			t.Errorf("expected identical responses, got %+v and %+v", quiet, verbose)
+		}
+	})
+}
+
+func TestValidateLogLevel(t *testing.T) {
+	p := &JiraPlugin{}
+	for _, tt := range []struct {
+		level string
+		valid bool
+	}{{"debug", true}, {"OFF", true}, {"trace", false}} {
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"base_url":    "https://company.atlassian.net",
+			"project_key": "PROJ",
+			"username":    "user@example.com",
+			"token":       "token",
+			"log_level":   tt.level,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Valid != tt.valid {
+			t.Errorf("log_level %q: expected valid=%v, got errors %v", tt.level, tt.valid, resp.Errors)
+		}
+	}
+}
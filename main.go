@@ -2,6 +2,9 @@
 package main
 
 import (
+	// Embed the time zone database so timezone works on hosts without one
+	_ "time/tzdata"
+
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
 )
 
@@ -3,23 +3,39 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	jira "github.com/felixgeelhaar/jirasdk"
 	"github.com/felixgeelhaar/jirasdk/core/issue"
+	"github.com/felixgeelhaar/jirasdk/core/permission"
 	"github.com/felixgeelhaar/jirasdk/core/project"
+	"github.com/felixgeelhaar/jirasdk/core/search"
+	"github.com/felixgeelhaar/jirasdk/transport"
 
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
 )
 
 // JiraPlugin implements the Jira integration plugin.
-type JiraPlugin struct{}
+type JiraPlugin struct {
+	mu sync.Mutex
+	// summaries holds the PostPublish summary of each release version until OnSuccess or
+	// OnError reports it.
+	summaries map[string]*releaseSummary
+}
 
 // Config represents the Jira plugin configuration.
 type Config struct {
@@ -31,6 +47,13 @@ type Config struct {
 	Token string `json:"token,omitempty"`
 	// ProjectKey is the Jira project key (e.g., "PROJ").
 	ProjectKey string `json:"project_key,omitempty"`
+	// ProjectKeys lists every project a release spans. Versions are created, associated and
+	// released in each project, and only issue keys with one of these prefixes are collected.
+	// ProjectKey alone is a single-project shorthand.
+	ProjectKeys []string `json:"project_keys,omitempty"`
+	// InferProjectFromIssues uses the most common prefix of the extracted issue keys as the
+	// project when neither ProjectKey nor ProjectKeys is set.
+	InferProjectFromIssues bool `json:"infer_project_from_issues"`
 	// VersionName is the name for the Jira version/release (default: version string).
 	VersionName string `json:"version_name,omitempty"`
 	// VersionDescription is the description for the Jira version.
@@ -43,25 +66,321 @@ type Config struct {
 	TransitionIssues bool `json:"transition_issues"`
 	// TransitionName is the transition name to apply (e.g., "Done", "Closed", "Released").
 	TransitionName string `json:"transition_name,omitempty"`
+	// TransitionID is the workflow transition ID to apply instead of looking up TransitionName.
+	TransitionID string `json:"transition_id,omitempty"`
+	// TransitionResolution is the resolution (e.g. "Fixed") set by the transition, for
+	// transition screens that require one.
+	TransitionResolution string `json:"transition_resolution,omitempty"`
 	// AddComment adds a comment to linked issues.
 	AddComment bool `json:"add_comment"`
 	// CommentTemplate is the comment template (supports {version}, {release_url} placeholders).
 	CommentTemplate string `json:"comment_template,omitempty"`
+	// BreakingCommentTemplate replaces CommentTemplate for issues referenced only by breaking changes.
+	BreakingCommentTemplate string `json:"breaking_comment_template,omitempty"`
+	// RevertCommentTemplate replaces CommentTemplate for issues referenced by revert commits.
+	RevertCommentTemplate string `json:"revert_comment_template,omitempty"`
+	// CreatedCommentTemplate replaces CommentTemplate when the version was not released in this run.
+	CreatedCommentTemplate string `json:"created_comment_template,omitempty"`
+	// ReleasedCommentTemplate replaces CommentTemplate when the version was released in this run.
+	ReleasedCommentTemplate string `json:"released_comment_template,omitempty"`
 	// IssuePattern is a regex pattern to extract issue keys from commits (default: project-\\d+).
 	IssuePattern string `json:"issue_pattern,omitempty"`
+	// IssuePatterns are further issue key patterns, matched together with IssuePattern.
+	IssuePatterns []string `json:"issue_patterns,omitempty"`
+	// IssueCategories limits the change categories scanned for issue keys (e.g. "features",
+	// "fixes"); empty scans every category.
+	IssueCategories []string `json:"issue_categories,omitempty"`
 	// AssociateIssues associates extracted issues with the version.
 	AssociateIssues bool `json:"associate_issues"`
+	// DryRunVerify performs read-only Jira calls during dry run to confirm the plan.
+	DryRunVerify bool `json:"dry_run_verify"`
+	// ClockSkewTolerance is how far (in seconds) the local clock may run ahead of the
+	// Jira server before the computed release date is clamped to the server's date.
+	ClockSkewTolerance int `json:"clock_skew_tolerance_seconds"`
+	// VerifyPermissions checks the user's project permissions before performing any writes.
+	VerifyPermissions bool `json:"verify_permissions"`
+	// ReleaseURLTemplate overrides the {release_url} value (supports the other comment placeholders).
+	ReleaseURLTemplate string `json:"release_url_template,omitempty"`
+	// SkipClosedBoardIssues skips issues whose sprints are all closed (requires Jira Software).
+	SkipClosedBoardIssues bool `json:"skip_closed_board_issues"`
+	// DryRunVersions overrides the global dry run for version creation and release.
+	DryRunVersions *bool `json:"dry_run_versions,omitempty"`
+	// DryRunAssociations overrides the global dry run for issue/version association.
+	DryRunAssociations *bool `json:"dry_run_associations,omitempty"`
+	// DryRunTransitions overrides the global dry run for issue transitions.
+	DryRunTransitions *bool `json:"dry_run_transitions,omitempty"`
+	// DryRunComments overrides the global dry run for issue comments.
+	DryRunComments *bool `json:"dry_run_comments,omitempty"`
+	// ThreadUnderRoot posts a root comment once per issue and references it from release comments.
+	ThreadUnderRoot bool `json:"thread_under_root"`
+	// ForbidIPBaseURL rejects base URLs whose host is an IP literal, requiring DNS names.
+	ForbidIPBaseURL bool `json:"forbid_ip_base_url"`
+	// FollowMovedIssues retries operations on issues Jira reports as missing against the
+	// issue's current key, resolved by searching for the old key.
+	FollowMovedIssues bool `json:"follow_moved_issues"`
+	// StrictTransition requires transition_name when transition_issues is set (default: true).
+	// When false, an empty transition name disables transitions instead.
+	StrictTransition bool `json:"strict_transition"`
+	// BulkAssociateThreshold is the issue count above which issues are associated with the
+	// version in bulk edit tasks instead of one by one (0, the default, disables bulk edits).
+	BulkAssociateThreshold int `json:"bulk_associate_threshold"`
+	// RedactBaseURL masks the Jira host in messages and outputs reported back to the host.
+	RedactBaseURL bool `json:"redact_base_url"`
+	// OnAmbiguousVersion chooses between versions sharing version_name:
+	// "fail" (default), "prefer_unreleased" or "prefer_newest".
+	OnAmbiguousVersion string `json:"on_ambiguous_version,omitempty"`
+	// CaptureSnapshot records each issue's status and fix versions before any writes.
+	CaptureSnapshot bool `json:"capture_snapshot"`
+	// AutoCreateMissingVersion creates the version when create_version is disabled but
+	// releasing or associating issues needs a version that does not exist.
+	AutoCreateMissingVersion bool `json:"auto_create_missing_version"`
+	// InstanceKeyMap maps issue key prefixes to the base URL of the Jira instance hosting
+	// them. When set, keys outside project_key and the mapped prefixes are skipped.
+	InstanceKeyMap map[string]string `json:"instance_key_map,omitempty"`
+	// NormalizeCommentUnicode replaces 4-byte UTF-8 characters (e.g. emoji) in comments.
+	NormalizeCommentUnicode bool `json:"normalize_comment_unicode"`
+	// StripVersionPrefix trims a leading "v" or "V" from the Jira version name (not the tag).
+	StripVersionPrefix bool `json:"strip_version_prefix"`
+	// WebhookSecret is the shared secret used to verify signed Jira webhooks.
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+	// VerboseMessage lists every skipped issue in the message instead of only the summary.
+	VerboseMessage bool `json:"verbose_message"`
+	// ReadOnly blocks every Jira write regardless of dry run settings; reads and planning still run.
+	ReadOnly bool `json:"read_only"`
+	// CreateVersionOnlyIfIssues defers version creation (and release) until there are issues to associate.
+	CreateVersionOnlyIfIssues bool `json:"create_version_only_if_issues"`
+	// MaxRetries is the number of retries for transient network errors and 5xx responses.
+	MaxRetries int `json:"max_retries"`
+	// CommentOnClosed posts comments on issues already in the done status category.
+	CommentOnClosed bool `json:"comment_on_closed"`
+	// OnExistingVersion decides whether an existing version with the same name is reused ("reuse")
+	// or a new one is created under a suffixed name such as "1.2.3 (2)" ("suffix").
+	OnExistingVersion string `json:"on_existing_version,omitempty"`
+	// NoIssuesComment is posted to NoIssuesIssue when the release references no issues.
+	NoIssuesComment string `json:"no_issues_comment,omitempty"`
+	// NoIssuesIssue is the fallback issue for NoIssuesComment; without it the comment is skipped.
+	NoIssuesIssue string `json:"no_issues_issue,omitempty"`
+	// ClientCertFile is a PEM client certificate presented to mTLS gateways.
+	ClientCertFile string `json:"client_cert_file,omitempty"`
+	// ClientKeyFile is the PEM private key for ClientCertFile.
+	ClientKeyFile string `json:"client_key_file,omitempty"`
+	// ProxyURL is the proxy Jira requests are sent through, overriding HTTPS_PROXY/HTTP_PROXY.
+	// base_url checks still apply to the Jira host, not the proxy.
+	ProxyURL string `json:"proxy_url,omitempty"`
+	// AuthType selects basic auth with username and token ("basic") or a personal access
+	// token sent as a Bearer token without a username ("bearer").
+	AuthType string `json:"auth_type,omitempty"`
+	// AllowPrivateHosts lets base_url resolve to private or loopback addresses, for self-hosted Jira.
+	AllowPrivateHosts bool `json:"allow_private_hosts"`
+	// AllowedHosts lists hostnames and CIDRs that may resolve to private addresses.
+	// Cloud metadata endpoints stay blocked unless listed here by name or address.
+	AllowedHosts []string `json:"allowed_hosts,omitempty"`
+	// VersionMatchMode is how existing version names are matched: "exact", "contains" or "prefix".
+	VersionMatchMode string `json:"version_match_mode,omitempty"`
+	// TimeoutSeconds bounds each Jira API request, including reading the response body.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// FailFast aborts the run at the first failed issue operation instead of continuing with
+	// the remaining issues. Issues are then processed one by one, ignoring Concurrency.
+	FailFast bool `json:"fail_fast"`
+	// VersionField is the issue field the release version is set on: "fix" (fixVersions) or
+	// "affects" (versions).
+	VersionField string `json:"version_field,omitempty"`
+	// DisableStandardDenylist keeps keys such as UTF-8 or SHA-256 that the default pattern
+	// otherwise ignores as standard identifiers.
+	DisableStandardDenylist bool `json:"disable_standard_denylist"`
+	// VerifyConnection makes Validate check the credentials and project access against Jira.
+	VerifyConnection bool `json:"verify_connection"`
+	// TrailerKeys names commit trailers (e.g. "Jira", "Refs") whose values are scanned for issue
+	// keys case-insensitively.
+	TrailerKeys []string `json:"trailer_keys,omitempty"`
+	// CommentStrategy is how releases are announced on issues: a comment on each issue
+	// ("per_issue"), a single comment on SummaryCommentIssue ("summary_only"), or both ("both").
+	CommentStrategy string `json:"comment_strategy,omitempty"`
+	// SummaryCommentIssue is the issue receiving the summary comment listing every released issue.
+	SummaryCommentIssue string `json:"summary_comment_issue,omitempty"`
+	// Timezone is the IANA time zone (e.g. "Europe/Berlin") used for release dates and {date};
+	// empty means UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// MaxKeysPerCommit skips the keys of any commit referencing more distinct issue keys than
+	// this, which usually indicates a malformed body; 0 means unlimited.
+	MaxKeysPerCommit int `json:"max_keys_per_commit"`
+	// VersionVisibilityTimeout is how long (in seconds) to wait for a newly created version to
+	// become readable before issues are associated with it; 0 disables the check.
+	VersionVisibilityTimeout int `json:"version_visibility_timeout_seconds"`
+	// CommentFormat is how comment bodies are converted to ADF: "text" posts them verbatim,
+	// "adf" turns links and **bold** markup into formatted text.
+	CommentFormat string `json:"comment_format,omitempty"`
+	// IdempotentComments skips comments whose body is already posted on the issue, so retried
+	// releases do not repeat them. Each commented issue's comments are fetched first.
+	IdempotentComments bool `json:"idempotent_comments"`
+	// PrimaryCommentTemplate, when set, replaces the comment on the release's primary issue,
+	// chosen by PrimaryIssueSelector; the other issues keep the regular comment.
+	PrimaryCommentTemplate string `json:"primary_comment_template,omitempty"`
+	// PrimaryIssueSelector picks the primary issue: the first referenced ("first_seen"), the
+	// lowest key ("lowest_key"), or the most recently updated in Jira ("most_recent").
+	PrimaryIssueSelector string `json:"primary_issue_selector,omitempty"`
+	// ReleaseDate is the release date set on the version: a date ("2024-03-01"), an RFC 3339
+	// timestamp, or "today". Empty means today.
+	ReleaseDate string `json:"release_date,omitempty"`
+	// LogLevel is the lowest level logged to stderr: "debug" (every Jira API call), "info"
+	// (hook summaries), "warn", "error" or "off". Empty means info.
+	LogLevel string `json:"log_level,omitempty"`
+	// NormalizeSeparators also extracts keys written with a space or underscore instead of a
+	// dash (e.g. "PROJ 123"), for projects that are configured or referenced as PROJ-n.
+	NormalizeSeparators bool `json:"normalize_separators"`
+	// SkipInvalidTransitions skips issues whose current status does not offer the configured
+	// transition instead of failing them.
+	SkipInvalidTransitions bool `json:"skip_invalid_transitions"`
+	// AddLabels adds the label rendered from LabelTemplate to each released issue.
+	AddLabels bool `json:"add_labels"`
+	// LabelTemplate is rendered with the comment placeholders, e.g. "released-{version}".
+	LabelTemplate string `json:"label_template,omitempty"`
+	// ArchivePreviousVersion archives the most recent released version created before the
+	// current one once the current version is released.
+	ArchivePreviousVersion bool `json:"archive_previous_version"`
+	// CommentVisibilityType restricts posted comments to a "role" or "group", named by
+	// CommentVisibilityValue. Comments are visible to all users when both are empty.
+	CommentVisibilityType  string `json:"comment_visibility_type,omitempty"`
+	CommentVisibilityValue string `json:"comment_visibility_value,omitempty"`
+	// IssueSource selects where PostPublish finds the release's issues: "commits" (default)
+	// scans commit messages, "jql" runs JQLQuery against Jira.
+	IssueSource string `json:"issue_source,omitempty"`
+	// JQLQuery finds the release's issues when IssueSource is "jql". It supports the comment
+	// placeholders, with {version} as the Jira version name, and {project}.
+	JQLQuery string `json:"jql_query,omitempty"`
+	// UserAgent overrides the User-Agent header of Jira requests.
+	UserAgent string `json:"user_agent,omitempty"`
+	// VersionStartDate is the start date set on versions the plugin creates, in the same
+	// formats as ReleaseDate. Empty leaves the start date unset.
+	VersionStartDate string `json:"version_start_date,omitempty"`
+	// RequestsPerSecond caps the rate of requests that change Jira data across the whole run.
+	// Zero (the default) sends them unthrottled.
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+	// APIVersion is the Jira REST API version requests are sent to: "3", "2" for Jira Server and
+	// Data Center deployments without v3, or "auto" (default) to detect it from the server.
+	APIVersion string `json:"api_version,omitempty"`
+	// CommentOnSuccess posts SuccessCommentTemplate to every issue of the release from the
+	// OnSuccess hook, once the whole pipeline has succeeded.
+	CommentOnSuccess bool `json:"comment_on_success"`
+	// SuccessCommentTemplate is the comment posted by CommentOnSuccess, with the same
+	// placeholders as CommentTemplate.
+	SuccessCommentTemplate string `json:"success_comment_template,omitempty"`
+	// TransitionOnError rolls the release's issues back from the OnError hook: each issue is
+	// moved with ErrorTransitionName and receives ErrorCommentTemplate noting the failure.
+	TransitionOnError bool `json:"transition_on_error"`
+	// ErrorTransitionName is the transition applied by TransitionOnError (e.g., "Reopen").
+	ErrorTransitionName string `json:"error_transition_name,omitempty"`
+	// ErrorCommentTemplate is the comment posted by TransitionOnError; empty uses
+	// defaultErrorComment.
+	ErrorCommentTemplate string `json:"error_comment_template,omitempty"`
+	// SetComponent adds the component named by ComponentName to each released issue.
+	SetComponent bool `json:"set_component"`
+	// ComponentName is the component added by SetComponent, with the same placeholders as
+	// comments (e.g., "{repository}"). The component must already exist in the project.
+	ComponentName string `json:"component_name,omitempty"`
+	// Concurrency is the number of issues whose operations run at once during PostPublish.
+	Concurrency int `json:"concurrency"`
+	// RequireIssues fails PostPublish when the release references no Jira issues.
+	RequireIssues bool `json:"require_issues"`
+	// KeywordMode only extracts issue keys that follow one of Keywords, as in "Closes PROJ-123".
+	KeywordMode bool `json:"keyword_mode"`
+	// Keywords are the keywords recognized by KeywordMode (default: closes, fixes, resolves).
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// actionDryRun holds the effective dry-run setting of each PostPublish action.
+type actionDryRun struct {
+	Versions     bool
+	Associations bool
+	Transitions  bool
+	Comments     bool
+}
+
+// all reports whether every action is in dry-run mode.
+func (a actionDryRun) all() bool {
+	return a.Versions && a.Associations && a.Transitions && a.Comments
+}
+
+// dryRunModes resolves the per-action dry-run overrides against the global dry run flag.
+// Read-only mode forces every action into dry run and cannot be overridden.
+func (c *Config) dryRunModes(dryRun bool) actionDryRun {
+	if c.ReadOnly {
+		return actionDryRun{Versions: true, Associations: true, Transitions: true, Comments: true}
+	}
+	pick := func(override *bool) bool {
+		if override != nil {
+			return *override
+		}
+		return dryRun
+	}
+	return actionDryRun{
+		Versions:     pick(c.DryRunVersions),
+		Associations: pick(c.DryRunAssociations),
+		Transitions:  pick(c.DryRunTransitions),
+		Comments:     pick(c.DryRunComments),
+	}
+}
+
+// skipVersionCreation reports whether version creation is skipped because no issues reference the release.
+func (c *Config) skipVersionCreation(issueKeys []string) bool {
+	return c.CreateVersion && c.CreateVersionOnlyIfIssues && len(issueKeys) == 0
+}
+
+// projects returns the keys of the projects a release spans, primary project first.
+func (c *Config) projects() []string {
+	if len(c.ProjectKeys) > 0 {
+		return c.ProjectKeys
+	}
+	return []string{c.ProjectKey}
 }
 
+// inferProject sets the project to the most common prefix among issueKeys, preferring the
+// earliest referenced on ties, when infer_project_from_issues is enabled and no project is
+// configured. It reports whether a project was inferred.
+func (c *Config) inferProject(issueKeys []string) bool {
+	if !c.InferProjectFromIssues || c.ProjectKey != "" || len(issueKeys) == 0 {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, issueKey := range issueKeys {
+		counts[issuePrefix(issueKey)]++
+	}
+	for _, issueKey := range issueKeys {
+		if prefix := issuePrefix(issueKey); counts[prefix] > counts[c.ProjectKey] {
+			c.ProjectKey = prefix
+		}
+	}
+	return true
+}
+
+// multiProject reports whether the release spans more than one project.
+func (c *Config) multiProject() bool {
+	return len(c.ProjectKeys) > 1
+}
+
+// configuredProject returns the configured project matching an issue key prefix.
+func (c *Config) configuredProject(prefix string) (string, bool) {
+	for _, projectKey := range c.projects() {
+		if projectKey != "" && strings.EqualFold(prefix, projectKey) {
+			return projectKey, true
+		}
+	}
+	return "", false
+}
+
+// pluginVersion is the version of the plugin.
+const pluginVersion = "2.0.0"
+
 // GetInfo returns plugin metadata.
 func (p *JiraPlugin) GetInfo() plugin.Info {
 	return plugin.Info{
 		Name:        "jira",
-		Version:     "2.0.0",
+		Version:     pluginVersion,
 		Description: "Integrate with Jira for version management and issue tracking",
 		Author:      "Relicta Team",
 		Hooks: []plugin.Hook{
 			plugin.HookPostPlan,
+			plugin.HookPreVersion,
 			plugin.HookPostPublish,
 			plugin.HookOnSuccess,
 			plugin.HookOnError,
@@ -73,18 +392,111 @@ func (p *JiraPlugin) GetInfo() plugin.Info {
 				"username": {"type": "string", "description": "Jira username (email for Atlassian Cloud)"},
 				"token": {"type": "string", "description": "Jira API token (or use JIRA_TOKEN env)"},
 				"project_key": {"type": "string", "description": "Jira project key (e.g., 'PROJ')"},
+				"project_keys": {"type": "array", "items": {"type": "string"}, "description": "Jira project keys a release spans; versions are managed in each project and only their issue keys are collected"},
 				"version_name": {"type": "string", "description": "Version name (default: version string)"},
 				"version_description": {"type": "string", "description": "Version description"},
 				"create_version": {"type": "boolean", "description": "Create a new version in Jira", "default": true},
 				"release_version": {"type": "boolean", "description": "Mark version as released", "default": true},
+				"archive_previous_version": {"type": "boolean", "description": "Archive the previous released version once the current version is released", "default": false},
 				"transition_issues": {"type": "boolean", "description": "Transition linked issues", "default": false},
 				"transition_name": {"type": "string", "description": "Transition name (e.g., 'Done', 'Released')"},
+				"transition_id": {"type": "string", "description": "Workflow transition ID, used instead of transition_name when names are ambiguous"},
+				"skip_invalid_transitions": {"type": "boolean", "description": "Skip issues whose current status does not offer the configured transition instead of failing them", "default": false},
+				"transition_resolution": {"type": "string", "description": "Resolution set when transitioning issues (e.g., 'Fixed')"},
+				"add_labels": {"type": "boolean", "description": "Add the label rendered from label_template to linked issues", "default": false},
+				"comment_on_success": {"type": "boolean", "description": "Comment on linked issues from the on_success hook, after every publisher finished (separate from add_comment)", "default": false},
+				"success_comment_template": {"type": "string", "description": "Comment posted by comment_on_success, with the same placeholders as comment_template"},
+				"transition_on_error": {"type": "boolean", "description": "On a failed release, transition linked issues with error_transition_name and comment on them", "default": false},
+				"error_transition_name": {"type": "string", "description": "Transition applied by transition_on_error (e.g., 'Reopen')"},
+				"error_comment_template": {"type": "string", "description": "Comment posted by transition_on_error, with the same placeholders as comment_template", "default": "Release {version} failed."},
+				"set_component": {"type": "boolean", "description": "Add the component named by component_name to linked issues", "default": false},
+				"component_name": {"type": "string", "description": "Existing project component added to issues, with the same placeholders as comments (e.g., '{repository}')"},
+				"label_template": {"type": "string", "description": "Label added to issues, with the same placeholders as comments (e.g., 'released-{version}')"},
+				"comment_visibility_type": {"type": "string", "description": "Restrict posted comments to a role or group, named by comment_visibility_value", "enum": ["role", "group"]},
+				"comment_visibility_value": {"type": "string", "description": "Name of the role or group that can see posted comments (e.g., 'jira-developers')"},
 				"add_comment": {"type": "boolean", "description": "Add comment to linked issues", "default": false},
-				"comment_template": {"type": "string", "description": "Comment template with {version}, {release_url} placeholders"},
+				"comment_template": {"type": "string", "description": "Comment template with {version}, {release_url}, {date}, {versions}, {breaking_notes}, {sibling_issues}, {pull_request}, {released}, {component} placeholders"},
+				"breaking_comment_template": {"type": "string", "description": "Comment template for issues referenced only by breaking changes (supports {breaking_notes})"},
+				"revert_comment_template": {"type": "string", "description": "Comment template for issues referenced by revert commits"},
+				"created_comment_template": {"type": "string", "description": "Comment template used when the version was not released in this run"},
+				"released_comment_template": {"type": "string", "description": "Comment template used when the version was released in this run"},
+				"issue_source": {"type": "string", "description": "Where to find the release's issues: commit messages, or a JQL search in Jira", "enum": ["commits", "jql"], "default": "commits"},
+				"jql_query": {"type": "string", "description": "JQL finding the release's issues when issue_source is jql (e.g., 'project = PROJ AND fixVersion = \"{version}\"')"},
 				"issue_pattern": {"type": "string", "description": "Regex pattern to extract issue keys"},
-				"associate_issues": {"type": "boolean", "description": "Associate issues with the version", "default": true}
+				"keyword_mode": {"type": "boolean", "description": "Only extract issue keys that follow a keyword, as in 'Closes PROJ-123' or 'Fixes: PROJ-45'", "default": false},
+				"keywords": {"type": "array", "items": {"type": "string"}, "description": "Keywords recognized by keyword_mode, matched case-insensitively", "default": ["closes", "fixes", "resolves"]},
+				"issue_categories": {"type": "array", "items": {"type": "string", "enum": ["features", "fixes", "breaking", "performance", "refactor", "docs", "other"]}, "description": "Change categories whose commits contribute issue keys; empty scans every category"},
+				"issue_patterns": {"type": "array", "items": {"type": "string"}, "description": "Regex patterns to extract issue keys, merged with issue_pattern; (?P<project>...) and (?P<number>...) groups build the key from other notations"},
+				"associate_issues": {"type": "boolean", "description": "Associate issues with the version", "default": true},
+				"dry_run_verify": {"type": "boolean", "description": "Perform read-only Jira calls during dry run to resolve transitions", "default": false},
+				"clock_skew_tolerance_seconds": {"type": "integer", "description": "Allowed local clock lead over the Jira server before the release date is clamped", "default": 300},
+				"verify_permissions": {"type": "boolean", "description": "Check project permissions before performing writes", "default": false},
+				"release_url_template": {"type": "string", "description": "Template overriding {release_url} (e.g., 'https://github.com/org/repo/releases/tag/{tag}')"},
+				"skip_closed_board_issues": {"type": "boolean", "description": "Skip issues that only belong to closed sprints", "default": false},
+				"dry_run_versions": {"type": "boolean", "description": "Override dry run for version creation/release"},
+				"dry_run_associations": {"type": "boolean", "description": "Override dry run for issue association"},
+				"dry_run_transitions": {"type": "boolean", "description": "Override dry run for issue transitions"},
+				"dry_run_comments": {"type": "boolean", "description": "Override dry run for issue comments"},
+				"thread_under_root": {"type": "boolean", "description": "Reference a per-issue root comment from release comments", "default": false},
+				"forbid_ip_base_url": {"type": "boolean", "description": "Reject base URLs whose host is an IP address", "default": false},
+				"follow_moved_issues": {"type": "boolean", "description": "Resolve moved issues by their old key and retry against the current key", "default": false},
+				"strict_transition": {"type": "boolean", "description": "Require transition_name when transition_issues is enabled; when false, an empty name disables transitions", "default": true},
+				"bulk_associate_threshold": {"type": "integer", "description": "Associate issues via Jira Cloud bulk edit when more than this many issues are found (0 disables)", "default": 0},
+				"redact_base_url": {"type": "boolean", "description": "Mask the Jira host in messages and outputs", "default": false},
+				"on_ambiguous_version": {"type": "string", "enum": ["fail", "prefer_unreleased", "prefer_newest"], "description": "How to choose between versions sharing the same name", "default": "fail"},
+				"capture_snapshot": {"type": "boolean", "description": "Record each issue's status and fix versions before changing them", "default": false},
+				"auto_create_missing_version": {"type": "boolean", "description": "Create the version when create_version is disabled but a missing version is needed", "default": false},
+				"instance_key_map": {"type": "object", "additionalProperties": {"type": "string"}, "description": "Issue key prefix to base URL of the Jira instance hosting it"},
+				"normalize_comment_unicode": {"type": "boolean", "description": "Replace emoji and other 4-byte UTF-8 characters in comments", "default": false},
+				"strip_version_prefix": {"type": "boolean", "description": "Trim a leading 'v' from the Jira version name", "default": false},
+				"webhook_secret": {"type": "string", "description": "Shared secret for verifying signed Jira webhooks"},
+				"verbose_message": {"type": "boolean", "description": "List every skipped issue in the message", "default": false},
+				"read_only": {"type": "boolean", "description": "Block all Jira writes regardless of dry run settings", "default": false},
+				"create_version_only_if_issues": {"type": "boolean", "description": "Only create the version when the release references Jira issues", "default": false},
+				"max_retries": {"type": "integer", "description": "Retries for transient network errors (timeouts, connection resets, EOF) and 5xx responses", "default": 3},
+				"comment_on_closed": {"type": "boolean", "description": "Comment on issues that are already in a done status", "default": true},
+				"on_existing_version": {"type": "string", "enum": ["reuse", "suffix"], "description": "Reuse an existing version with the same name, or create one with an incrementing suffix", "default": "reuse"},
+				"require_issues": {"type": "boolean", "description": "Fail PostPublish with error_code no_issues when the release references no Jira issues", "default": false},
+				"no_issues_comment": {"type": "string", "description": "Comment posted to no_issues_issue when the release references no Jira issues"},
+				"no_issues_issue": {"type": "string", "description": "Fallback issue key for no_issues_comment; the comment is skipped when unset"},
+				"client_cert_file": {"type": "string", "description": "PEM client certificate for mTLS"},
+				"client_key_file": {"type": "string", "description": "PEM private key for client_cert_file"},
+				"proxy_url": {"type": "string", "description": "Proxy for Jira requests (e.g., 'http://proxy.internal:3128'); defaults to HTTPS_PROXY/HTTP_PROXY"},
+				"api_version": {"type": ["string", "integer"], "enum": ["auto", "2", "3", 2, 3], "description": "Jira REST API version: 3 (Cloud), 2 (Server/Data Center without v3), or auto to probe /rest/api/3/serverInfo and fall back to 2", "default": "auto"},
+				"auth_type": {"type": "string", "enum": ["basic", "bearer"], "description": "Basic auth with username and token, or a Bearer personal access token (Data Center)", "default": "basic"},
+				"allow_private_hosts": {"type": "boolean", "description": "Allow base_url to resolve to private network addresses", "default": false},
+				"allowed_hosts": {"type": "array", "items": {"type": "string"}, "description": "Hostnames or CIDRs allowed to resolve to private network addresses"},
+				"version_match_mode": {"type": "string", "enum": ["exact", "contains", "prefix"], "description": "How existing version names are matched against the release version", "default": "exact"},
+				"user_agent": {"type": "string", "description": "User-Agent header of Jira requests", "default": "relicta-jira-plugin/2.0.0"},
+				"concurrency": {"type": "integer", "minimum": 1, "maximum": 32, "description": "Number of issues updated at once during PostPublish; 1 processes issues one by one", "default": 4},
+				"requests_per_second": {"type": "number", "minimum": 0, "description": "Maximum rate of requests that change Jira data, shared across all issues of a release (0 = unlimited)", "default": 0},
+				"timeout_seconds": {"type": "integer", "description": "Timeout in seconds for each Jira API request", "default": 30, "maximum": 300},
+				"trailer_keys": {"type": "array", "items": {"type": "string"}, "description": "Commit trailers (e.g. Jira, Refs, Fixes) whose values are scanned for issue keys case-insensitively"},
+				"fail_fast": {"type": "boolean", "description": "Abort at the first failed issue operation instead of continuing with the remaining issues", "default": false},
+				"verify_connection": {"type": "boolean", "description": "Check credentials and project access against Jira during validation", "default": false},
+				"disable_standard_denylist": {"type": "boolean", "description": "Keep keys such as UTF-8, SHA-256 or ISO-8601 that the default issue pattern ignores as standard identifiers", "default": false},
+				"version_field": {"type": "string", "enum": ["fix", "affects"], "description": "Set the release as the issues' fix version or affects version", "default": "fix"},
+				"version_visibility_timeout_seconds": {"type": "integer", "minimum": 0, "maximum": 60, "description": "Seconds to wait for a created version to become readable before associating issues (0 = no check)", "default": 0},
+				"max_keys_per_commit": {"type": "integer", "minimum": 0, "description": "Ignore the keys of commits referencing more distinct issue keys than this (0 = unlimited)", "default": 0},
+				"timezone": {"type": "string", "description": "IANA time zone for release dates and {date} (e.g., 'Europe/Berlin')", "default": "UTC"},
+				"infer_project_from_issues": {"type": "boolean", "description": "Use the most common issue key prefix as the project when project_key is not set", "default": false},
+				"normalize_separators": {"type": "boolean", "description": "Treat keys written as 'PROJ 123' or 'PROJ_123' as PROJ-123 when the project is configured or referenced with a dash", "default": false},
+				"log_level": {"type": "string", "enum": ["debug", "info", "warn", "error", "off"], "description": "Lowest level logged to stderr; debug logs every Jira API call", "default": "info"},
+				"release_date": {"type": "string", "description": "Release date set when releasing the version: YYYY-MM-DD, an RFC 3339 timestamp, or 'today'", "default": "today"},
+				"version_start_date": {"type": "string", "description": "Start date set on created versions: YYYY-MM-DD, an RFC 3339 timestamp, or 'today'"},
+				"primary_comment_template": {"type": "string", "description": "Comment template for the primary issue only (same placeholders as comment_template)"},
+				"primary_issue_selector": {"type": "string", "enum": ["first_seen", "lowest_key", "most_recent"], "description": "How the issue receiving primary_comment_template is chosen", "default": "first_seen"},
+				"idempotent_comments": {"type": "boolean", "description": "Skip comments already posted on the issue with an identical body (costs one request per issue)", "default": true},
+				"comment_format": {"type": "string", "enum": ["text", "adf"], "description": "Post comments as plain text, or as rich text with links and **bold** (adf)", "default": "text"},
+				"comment_strategy": {"type": "string", "enum": ["per_issue", "summary_only", "both"], "description": "Comment on each issue, post one summary comment to summary_comment_issue, or both", "default": "per_issue"},
+				"summary_comment_issue": {"type": "string", "description": "Issue key receiving the summary comment that lists every released issue"}
 			},
-			"required": ["base_url", "project_key"]
+			"required": ["base_url"],
+			"anyOf": [
+				{"required": ["project_key"]},
+				{"required": ["project_keys"]},
+				{"required": ["infer_project_from_issues"], "properties": {"infer_project_from_issues": {"const": true}}}
+			]
 		}`,
 	}
 }
@@ -95,19 +507,20 @@ func (p *JiraPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*p
 
 	switch req.Hook {
 	case plugin.HookPostPlan:
-		return p.handlePostPlan(ctx, cfg, req.Context, req.DryRun)
+		resp, err := p.handlePostPlan(ctx, cfg, req.Context, req.DryRun)
+		return cfg.logResult(req.Hook, cfg.redactResponse(resp)), err
+	case plugin.HookPreVersion:
+		resp, err := p.handlePreVersion(ctx, cfg, req.Context, req.DryRun)
+		return cfg.logResult(req.Hook, cfg.redactResponse(resp)), err
 	case plugin.HookPostPublish:
-		return p.handlePostPublish(ctx, cfg, req.Context, req.DryRun)
+		resp, err := p.handlePostPublish(ctx, cfg, req.Context, req.DryRun)
+		return cfg.logResult(req.Hook, cfg.redactResponse(resp)), err
 	case plugin.HookOnSuccess:
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "Release successful - Jira integration acknowledged",
-		}, nil
+		resp, err := p.handleOnSuccess(ctx, cfg, req.Context, req.DryRun)
+		return cfg.logResult(req.Hook, cfg.redactResponse(resp)), err
 	case plugin.HookOnError:
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "Release failed - Jira integration acknowledged",
-		}, nil
+		resp, err := p.handleOnError(ctx, cfg, req.Context, req.DryRun)
+		return cfg.logResult(req.Hook, cfg.redactResponse(resp)), err
 	default:
 		return &plugin.ExecuteResponse{
 			Success: true,
@@ -120,31 +533,93 @@ func (p *JiraPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*p
 func (p *JiraPlugin) handlePostPlan(_ context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, _ bool) (*plugin.ExecuteResponse, error) {
 	// Extract issue keys from commits
 	issueKeys := p.extractIssueKeys(cfg, releaseCtx.Changes)
+	commitCount := len(allCommits(releaseCtx.Changes))
+	keyWarnings := keyLimitWarnings(cfg, releaseCtx.Changes)
 
 	if len(issueKeys) == 0 {
+		// Missing changes usually mean the plugin was not given the release's commits
+		message := fmt.Sprintf("No Jira issues found in %d commits", commitCount)
+		if releaseCtx.Changes == nil {
+			message = "No Jira issues found: the release has no changes (check that commits are passed to the plugin)"
+		}
+		outputs := map[string]any{
+			"issues_found": 0,
+			"commit_count": commitCount,
+		}
+		if len(keyWarnings) > 0 {
+			outputs["key_warnings"] = keyWarnings
+		}
 		return &plugin.ExecuteResponse{
 			Success: true,
-			Message: "No Jira issues found in commits",
-			Outputs: map[string]any{
-				"issues_found": 0,
-			},
+			Message: message,
+			Outputs: outputs,
 		}, nil
 	}
 
+	outputs := map[string]any{
+		"issues_found": len(issueKeys),
+		"issue_keys":   issueKeys,
+		"commit_count": commitCount,
+	}
+	if len(keyWarnings) > 0 {
+		outputs["key_warnings"] = keyWarnings
+	}
+	if cfg.KeywordMode {
+		outputs["issue_keywords"] = issueKeywords(cfg, releaseCtx.Changes, issueKeys)
+	}
+	// Links need base_url, which planning does not otherwise require
+	if cfg.BaseURL != "" {
+		links := make(map[string]string, len(issueKeys))
+		for _, issueKey := range issueKeys {
+			links[issueKey] = issueBrowseURL(cfg.BaseURL, issueKey)
+		}
+		outputs["issue_links"] = links
+	}
+	if len(cfg.ProjectKeys) > 0 {
+		projects := make(map[string]any, len(cfg.ProjectKeys))
+		for _, release := range projectReleases(cfg, issueKeys, cfg.jiraVersionName(releaseCtx)) {
+			projects[release.projectKey] = map[string]any{
+				"issues":       release.issues,
+				"version_name": release.versionName,
+			}
+		}
+		outputs["projects"] = projects
+	}
+
 	return &plugin.ExecuteResponse{
 		Success: true,
 		Message: fmt.Sprintf("Found %d Jira issue(s) linked to this release: %s", len(issueKeys), strings.Join(issueKeys, ", ")),
-		Outputs: map[string]any{
-			"issues_found": len(issueKeys),
-			"issue_keys":   issueKeys,
-		},
+		Outputs: outputs,
 	}, nil
 }
 
-// handlePostPublish handles the PostPublish hook - create/release version, update issues.
-func (p *JiraPlugin) handlePostPublish(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	// Create Jira client
-	client, err := p.getClient(cfg)
+// plannedIssue is an issue already assigned to the upcoming version in Jira.
+type plannedIssue struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+}
+
+// plannedIssuesPageSize is the number of issues requested per search page.
+const plannedIssuesPageSize = 100
+
+// handlePreVersion reports the issues already assigned to the upcoming version in Jira, so
+// Jira-tracked work can be included in the changelog. Dry runs skip the query.
+func (p *JiraPlugin) handlePreVersion(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	versionName := cfg.jiraVersionName(releaseCtx)
+	jql := cfg.versionJQL(versionName)
+	if dryRun {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: fmt.Sprintf("Would query Jira issues planned for version '%s'", versionName),
+			Outputs: map[string]any{
+				"version_name":   versionName,
+				"planned_issues": []plannedIssue{},
+				"jql":            jql,
+			},
+		}, nil
+	}
+
+	client, err := p.getClient(cfg, nil)
 	if err != nil {
 		return &plugin.ExecuteResponse{
 			Success: false,
@@ -152,293 +627,3861 @@ func (p *JiraPlugin) handlePostPublish(ctx context.Context, cfg *Config, release
 		}, nil
 	}
 
-	versionName := cfg.VersionName
-	if versionName == "" {
-		versionName = releaseCtx.Version
+	issues, err := p.plannedIssues(ctx, client, jql)
+	if hasStatus(err, http.StatusBadRequest) {
+		// Jira rejects JQL naming a version that does not exist yet
+		issues, err = []plannedIssue{}, nil
+	}
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
 	}
 
-	// Extract issue keys from commits
-	issueKeys := p.extractIssueKeys(cfg, releaseCtx.Changes)
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Found %d Jira issue(s) planned for version '%s'", len(issues), versionName),
+		Outputs: map[string]any{
+			"version_name":   versionName,
+			"planned_issues": issues,
+			"jql":            jql,
+		},
+	}, nil
+}
 
-	if dryRun {
-		actions := []string{}
-		if cfg.CreateVersion {
-			actions = append(actions, fmt.Sprintf("Create version '%s' in project %s", versionName, cfg.ProjectKey))
-		}
-		if cfg.ReleaseVersion {
-			actions = append(actions, fmt.Sprintf("Mark version '%s' as released", versionName))
+// Issue sources.
+const (
+	issueSourceCommits = "commits"
+	issueSourceJQL     = "jql"
+)
+
+// issueQuery renders jql_query for a release.
+func (c *Config) issueQuery(releaseCtx plugin.ReleaseContext) string {
+	query := strings.ReplaceAll(c.JQLQuery, "{version}", c.jiraVersionName(releaseCtx))
+	query = strings.ReplaceAll(query, "{project}", c.ProjectKey)
+	return substitutePlaceholders(query, releaseCtx)
+}
+
+// searchIssueKeys returns the keys of every issue matching jql.
+func (p *JiraPlugin) searchIssueKeys(ctx context.Context, client *jira.Client, jql string) ([]string, error) {
+	issues, err := p.plannedIssues(ctx, client, jql)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(issues))
+	for i, iss := range issues {
+		keys[i] = iss.Key
+	}
+	return keys, nil
+}
+
+// versionJQL returns the JQL matching issues whose fix or affects version (per version_field)
+// is versionName, limited to the configured projects.
+func (c *Config) versionJQL(versionName string) string {
+	field := "fixVersion"
+	if c.VersionField == versionFieldAffects {
+		field = "affectedVersion"
+	}
+	jql := fmt.Sprintf("%s = %q", field, versionName)
+
+	var projects []string
+	for _, projectKey := range c.projects() {
+		if projectKey != "" {
+			projects = append(projects, strconv.Quote(projectKey))
 		}
-		if cfg.AssociateIssues && len(issueKeys) > 0 {
-			actions = append(actions, fmt.Sprintf("Associate %d issues with version", len(issueKeys)))
+	}
+	if len(projects) > 0 {
+		jql = fmt.Sprintf("project in (%s) AND %s", strings.Join(projects, ", "), jql)
+	}
+	return jql + " ORDER BY key"
+}
+
+// plannedIssues returns the key and summary of every issue matching jql.
+func (p *JiraPlugin) plannedIssues(ctx context.Context, client *jira.Client, jql string) ([]plannedIssue, error) {
+	issues := []plannedIssue{}
+	opts := &search.SearchJQLOptions{
+		JQL:        jql,
+		Fields:     []string{"summary"},
+		MaxResults: plannedIssuesPageSize,
+	}
+	for {
+		result, err := client.Search.SearchJQL(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
 		}
-		if cfg.TransitionIssues && cfg.TransitionName != "" && len(issueKeys) > 0 {
-			actions = append(actions, fmt.Sprintf("Transition %d issues to '%s'", len(issueKeys), cfg.TransitionName))
+		for _, iss := range result.Issues {
+			issues = append(issues, plannedIssue{Key: iss.Key, Summary: iss.GetSummary()})
 		}
-		if cfg.AddComment && cfg.CommentTemplate != "" && len(issueKeys) > 0 {
-			actions = append(actions, fmt.Sprintf("Add comment to %d issues", len(issueKeys)))
+		if result.NextPageToken == "" || len(result.Issues) == 0 {
+			return issues, nil
 		}
+		opts.NextPageToken = result.NextPageToken
+	}
+}
 
+// handlePostPublish handles the PostPublish hook - create/release version, update issues.
+// Each run keeps its results and outputs in locals on the calling goroutine; state shared
+// across runs (such as the HTTP middlewares) is guarded by its own lock.
+func (p *JiraPlugin) handlePostPublish(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	// Create Jira client
+	clock := &serverClock{}
+	releaseHeader := releaseHeaderMiddleware(releaseCtx.Version)
+	limiter := newRequestLimiter(cfg.RequestsPerSecond)
+	client, err := p.getClient(cfg, limiter, clock.middleware(), releaseHeader)
+	if err != nil {
 		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: fmt.Sprintf("Would perform: %s", strings.Join(actions, "; ")),
-			Outputs: map[string]any{
-				"version_name": versionName,
-				"project_key":  cfg.ProjectKey,
-				"issues":       issueKeys,
-				"actions":      actions,
-			},
+			Success: false,
+			Error:   fmt.Sprintf("failed to create Jira client: %v", err),
 		}, nil
 	}
 
-	var versionID string
-	results := []string{}
+	versionName := cfg.jiraVersionName(releaseCtx)
 
-	// Create version if requested
-	if cfg.CreateVersion {
-		version, err := p.createOrGetVersion(ctx, client, cfg.ProjectKey, versionName, cfg.VersionDescription)
-		if err != nil {
+	// Extract issue keys from commits, or search Jira for them
+	issueKeys := p.extractIssueKeys(cfg, releaseCtx.Changes)
+	if cfg.IssueSource == issueSourceJQL {
+		if issueKeys, err = p.searchIssueKeys(ctx, client, cfg.issueQuery(releaseCtx)); err != nil {
 			return &plugin.ExecuteResponse{
 				Success: false,
-				Error:   fmt.Sprintf("failed to create/get version: %v", err),
+				Error:   fmt.Sprintf("failed to find issues with jql_query: %v", err),
 			}, nil
 		}
-		versionID = version.ID
-		results = append(results, fmt.Sprintf("Created/found version '%s'", versionName))
+	}
+	if cfg.RequireIssues && len(issueKeys) == 0 {
+		return noIssuesResponse(cfg), nil
+	}
+	if cfg.InferProjectFromIssues && cfg.ProjectKey == "" && len(issueKeys) == 0 {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "No Jira issues found to infer the project from; skipped Jira updates",
+			Outputs: map[string]any{
+				"issues": issueKeys,
+			},
+		}, nil
+	}
+	inferredProject := cfg.inferProject(issueKeys)
+	keyWarnings := keyLimitWarnings(cfg, releaseCtx.Changes)
+	var keywords map[string]string
+	if cfg.KeywordMode && cfg.IssueSource != issueSourceJQL {
+		keywords = issueKeywords(cfg, releaseCtx.Changes, issueKeys)
+	}
+	logger := cfg.logger()
+	logger.Info("publishing release", "version", versionName, "project", cfg.ProjectKey, "issues", len(issueKeys), "dry_run", dryRun)
+	summary := newReleaseSummary(versionName, issueKeys, dryRun)
+	p.trackRelease(releaseCtx.Version, summary)
+	for _, warning := range keyWarnings {
+		logger.Warn(warning)
 	}
 
-	// Release version if requested
-	if cfg.ReleaseVersion && versionID != "" {
-		err := p.releaseVersion(ctx, client, versionID)
-		if err != nil {
-			results = append(results, fmt.Sprintf("Failed to release version: %v", err))
-		} else {
-			results = append(results, fmt.Sprintf("Marked version '%s' as released", versionName))
+	// Route issues hosted on other Jira instances, dropping those without one
+	router, err := p.newIssueRouter(cfg, client, limiter, releaseHeader)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	totalIssues := len(issueKeys)
+	issueKeys, unmappedIssues := routeIssues(cfg, issueKeys)
+	skips := newIssueSkips()
+	outcomes := newIssueOutcomes()
+	for _, issueKey := range unmappedIssues {
+		skips.add(issueKey, "unmapped instance")
+	}
+
+	modes := cfg.dryRunModes(dryRun)
+	if modes.all() {
+		resp, err := p.planPostPublish(ctx, cfg, client, versionName, issueKeys, p.renderLabel(cfg, releaseCtx), p.renderComponent(cfg, releaseCtx))
+		summary.finish()
+		if resp != nil && resp.Outputs != nil && len(cfg.InstanceKeyMap) > 0 {
+			resp.Outputs["unmapped_issues"] = unmappedIssues
+		}
+		if resp != nil && resp.Outputs != nil && inferredProject {
+			resp.Outputs["project_key_inferred"] = true
 		}
+		if resp != nil && resp.Outputs != nil && len(keyWarnings) > 0 {
+			resp.Outputs["key_warnings"] = keyWarnings
+		}
+		if resp != nil && resp.Outputs != nil && keywords != nil {
+			resp.Outputs["issue_keywords"] = keywords
+		}
+		return resp, err
 	}
 
-	// Associate issues with version
-	if cfg.AssociateIssues && versionID != "" && len(issueKeys) > 0 {
-		successCount := 0
-		for _, issueKey := range issueKeys {
-			err := p.associateIssueWithVersion(ctx, client, issueKey, versionName)
-			if err == nil {
-				successCount++
+	// Fail early rather than partially applying the release. An inferred project is always
+	// checked since nothing says the credentials can manage versions there.
+	if cfg.VerifyPermissions || inferredProject {
+		for _, projectKey := range cfg.projects() {
+			if err := p.verifyPermissions(ctx, router.client(projectKey), projectKey, requiredPermissions(cfg, modes, len(issueKeys) > 0)); err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   err.Error(),
+				}, nil
 			}
 		}
-		results = append(results, fmt.Sprintf("Associated %d/%d issues with version", successCount, len(issueKeys)))
 	}
 
-	// Transition issues
-	if cfg.TransitionIssues && cfg.TransitionName != "" && len(issueKeys) > 0 {
-		successCount := 0
-		for _, issueKey := range issueKeys {
-			err := p.transitionIssue(ctx, client, issueKey, cfg.TransitionName)
-			if err == nil {
-				successCount++
+	results := []string{}
+	associated := issueVersions{}
+	// Why bulk association fell back to per-issue updates, by project
+	var bulkFallbacks map[string]string
+	moved := &movedIssues{enabled: cfg.FollowMovedIssues, keys: map[string]string{}}
+	steps := &issueSteps{
+		cfg:      cfg,
+		outcomes: outcomes,
+		skips:    skips,
+		summary:  summary,
+		// Stop starting operations on further issues once a result ends the run
+		stop: func(err error) bool {
+			if errors.Is(err, errCredentialsExpired) {
+				return true
 			}
-		}
-		results = append(results, fmt.Sprintf("Transitioned %d/%d issues to '%s'", successCount, len(issueKeys), cfg.TransitionName))
+			return cfg.FailFast && !isNotFound(err) && !(cfg.SkipInvalidTransitions && errors.Is(err, errTransitionUnavailable))
+		},
 	}
 
-	// Add comments to issues
-	if cfg.AddComment && cfg.CommentTemplate != "" && len(issueKeys) > 0 {
-		comment := p.buildComment(cfg.CommentTemplate, releaseCtx)
-		successCount := 0
+	// Drop issues that only belong to closed sprints before touching anything
+	var closedSprintIssues []string
+	if cfg.SkipClosedBoardIssues && len(issueKeys) > 0 {
+		var openIssues []string
 		for _, issueKey := range issueKeys {
-			err := p.addComment(ctx, client, issueKey, comment)
-			if err == nil {
-				successCount++
+			if p.inClosedSprint(ctx, router.client(issueKey), issueKey) {
+				closedSprintIssues = append(closedSprintIssues, issueKey)
+				skips.add(issueKey, "closed sprint")
+			} else {
+				openIssues = append(openIssues, issueKey)
 			}
 		}
-		results = append(results, fmt.Sprintf("Added comments to %d/%d issues", successCount, len(issueKeys)))
+		issueKeys = openIssues
+		if len(closedSprintIssues) > 0 {
+			results = append(results, fmt.Sprintf("Skipped %d issues in closed sprints", len(closedSprintIssues)))
+		}
 	}
 
-	return &plugin.ExecuteResponse{
-		Success: true,
-		Message: strings.Join(results, "; "),
-		Outputs: map[string]any{
-			"version_name": versionName,
-			"version_id":   versionID,
-			"project_key":  cfg.ProjectKey,
-			"issues":       issueKeys,
-		},
-	}, nil
-}
-
-// extractIssueKeys extracts Jira issue keys from commit messages.
-func (p *JiraPlugin) extractIssueKeys(cfg *Config, changes *plugin.CategorizedChanges) []string {
-	pattern := cfg.IssuePattern
-	if pattern == "" {
-		// Default pattern: PROJECT-123 (project key followed by hyphen and digits)
-		pattern = `[A-Z][A-Z0-9]*-\d+`
+	// Record issue states before any writes so the release can be rolled back
+	var snapshot map[string]issueSnapshot
+	if cfg.CaptureSnapshot && len(issueKeys) > 0 {
+		snapshot = p.captureSnapshot(ctx, router, issueKeys)
+		results = append(results, fmt.Sprintf("Captured snapshot of %d/%d issues", len(snapshot), len(issueKeys)))
 	}
 
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil
-	}
+	// Manage the version in each project the release spans
+	releases := projectReleases(cfg, issueKeys, versionName)
+	planned := issueVersions{}
+	releasedCount, releaseFailed := 0, false
+	date := ""
+	for _, release := range releases {
+		versionClient := router.client(release.projectKey)
+		scope := release.scope(cfg)
 
-	seen := make(map[string]bool)
-	var keys []string
+		// Pick an unused name rather than reusing an existing version
+		if cfg.CreateVersion && !release.skipped && cfg.OnExistingVersion == existingVersionSuffix {
+			name, err := p.freeVersionName(ctx, versionClient, release.projectKey, release.versionName)
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("failed to choose version name: %v", err),
+				}, nil
+			}
+			release.versionName = name
+		}
+
+		// Create version if requested
+		if release.skipped {
+			results = append(results, fmt.Sprintf("Skipped version '%s'%s: no issues to associate", release.versionName, scope))
+		} else if cfg.CreateVersion && modes.Versions {
+			// Look up an existing version so real actions can still reference it
+			version, err := p.findVersion(ctx, versionClient, release.projectKey, release.versionName, cfg.versionLookup())
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("failed to get version: %v", err),
+				}, nil
+			}
+			if version != nil {
+				release.versionID = version.ID
+				results = append(results, fmt.Sprintf("Found version '%s'%s", release.versionName, scope))
+			} else {
+				results = append(results, fmt.Sprintf("Would create version '%s' in project %s%s", release.versionName, release.projectKey, cfg.startDateNote()))
+			}
+		} else if cfg.CreateVersion {
+			version, err := p.createOrGetVersion(ctx, versionClient, release.projectKey, release.versionName, cfg.VersionDescription, cfg.versionStartDate(), cfg.versionLookup(), cfg.versionVisibilityTimeout())
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("failed to create/get version: %v", err),
+				}, nil
+			}
+			release.versionID = version.ID
+			results = append(results, fmt.Sprintf("Created/found version '%s'%s", release.versionName, scope))
+		} else if (cfg.ReleaseVersion && !modes.Versions) || (cfg.AssociateIssues && !modes.Associations && len(release.issues) > 0) {
+			// Releasing and associating need an existing version when creation is disabled
+			version, err := p.findVersion(ctx, versionClient, release.projectKey, release.versionName, cfg.versionLookup())
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("failed to get version: %v", err),
+				}, nil
+			}
+			switch {
+			case version != nil:
+				release.versionID = version.ID
+				results = append(results, fmt.Sprintf("Found version '%s'%s", release.versionName, scope))
+			case !cfg.AutoCreateMissingVersion:
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error: fmt.Sprintf("no matching version '%s' exists in project %s and create_version is disabled "+
+						"(enable create_version or auto_create_missing_version)", release.versionName, release.projectKey),
+				}, nil
+			case modes.Versions:
+				results = append(results, fmt.Sprintf("Would create missing version '%s' in project %s%s", release.versionName, release.projectKey, cfg.startDateNote()))
+			default:
+				version, err := p.createOrGetVersion(ctx, versionClient, release.projectKey, release.versionName, cfg.VersionDescription, cfg.versionStartDate(), cfg.versionLookup(), cfg.versionVisibilityTimeout())
+				if err != nil {
+					return &plugin.ExecuteResponse{
+						Success: false,
+						Error:   fmt.Sprintf("failed to create missing version: %v", err),
+					}, nil
+				}
+				release.versionID = version.ID
+				results = append(results, fmt.Sprintf("Created missing version '%s'%s", release.versionName, scope))
+			}
+		}
+
+		release.versionURL = versionBrowseURL(router.baseURL(release.projectKey), release.projectKey, release.versionID)
+		if release.versionID != "" && !modes.Versions {
+			summary.add(summaryVersions, 1)
+		}
+
+		// Release version if requested; the date is computed late so the server clock is known
+		if cfg.ReleaseVersion {
+			date = cfg.versionReleaseDate(clock)
+		}
+		if cfg.ReleaseVersion && modes.Versions && !release.skipped {
+			results = append(results, fmt.Sprintf("Would mark version '%s'%s as released on %s", release.versionName, scope, date))
+		} else if cfg.ReleaseVersion && release.versionID != "" {
+			err := p.releaseVersion(ctx, versionClient, release.versionID, date)
+			if err != nil {
+				releaseFailed = true
+				results = append(results, fmt.Sprintf("Failed to release version%s: %v", scope, err))
+			} else {
+				release.released = true
+				releasedCount++
+				summary.add(summaryVersionsReleased, 1)
+				results = append(results, fmt.Sprintf("Marked version '%s'%s as released on %s", release.versionName, scope, date))
+			}
+		}
 
-	// Helper function to extract from a slice of commits
-	extractFromCommits := func(commits []plugin.ConventionalCommit) {
-		for _, commit := range commits {
-			// Check description
-			matches := re.FindAllString(commit.Description, -1)
-			for _, match := range matches {
-				upperMatch := strings.ToUpper(match)
-				if !seen[upperMatch] {
-					seen[upperMatch] = true
-					keys = append(keys, upperMatch)
+		// Archive the previous version once this one is released
+		if cfg.ArchivePreviousVersion && cfg.ReleaseVersion && (release.released || (modes.Versions && !release.skipped)) {
+			previous, err := p.previousVersion(ctx, versionClient, release.projectKey, release.versionName)
+			switch {
+			case err != nil:
+				results = append(results, fmt.Sprintf("Failed to find previous version%s: %v", scope, err))
+			case previous == nil:
+				// Nothing was released before this version
+			case modes.Versions:
+				results = append(results, fmt.Sprintf("Would archive version '%s'%s", previous.Name, scope))
+			default:
+				if err := p.archiveVersion(ctx, versionClient, previous.ID); err != nil {
+					results = append(results, fmt.Sprintf("Failed to archive version '%s'%s: %v", previous.Name, scope, err))
+				} else {
+					release.archivedVersion = previous.Name
+					results = append(results, fmt.Sprintf("Archived version '%s'%s", previous.Name, scope))
 				}
 			}
-			// Also check body if present
-			if commit.Body != "" {
-				bodyMatches := re.FindAllString(commit.Body, -1)
-				for _, match := range bodyMatches {
-					upperMatch := strings.ToUpper(match)
-					if !seen[upperMatch] {
-						seen[upperMatch] = true
-						keys = append(keys, upperMatch)
+		}
+
+		// Associate issues with version
+		if cfg.AssociateIssues && modes.Associations && len(release.issues) > 0 {
+			for _, issueKey := range release.issues {
+				planned.add(issueKey, release.versionName)
+			}
+			results = append(results, fmt.Sprintf("Would associate %d issues with %s version '%s'%s", len(release.issues), cfg.VersionField, release.versionName, scope))
+		} else if cfg.AssociateIssues && release.versionID != "" && len(release.issues) > 0 {
+			successCount := 0
+			// Bulk edits go to a single instance, so they are only used without instance routing
+			bulk := cfg.BulkAssociateThreshold > 0 && len(release.issues) > cfg.BulkAssociateThreshold && !router.federated()
+			if bulk {
+				// Fall back to per-issue updates when bulk edit is unsupported or any issue failed
+				if err := p.bulkAssociateIssues(ctx, versionClient, release.issues, cfg.versionFieldID(), release.versionID); errors.Is(err, errCredentialsExpired) {
+					return credentialsExpiredResponse("associating issues", release.issues, notStarted(len(release.issues)), outcomes), nil
+				} else if err != nil {
+					logger.Warn("bulk association failed, falling back to per-issue updates", "project", release.projectKey, "issues", len(release.issues), "error", err)
+					if bulkFallbacks == nil {
+						bulkFallbacks = map[string]string{}
+					}
+					bulkFallbacks[release.projectKey] = err.Error()
+					bulk = false
+				} else {
+					for _, issueKey := range release.issues {
+						associated.add(issueKey, release.versionName)
+						outcomes.succeed(issueKey)
 					}
+					successCount = len(release.issues)
+					summary.add(summaryIssuesAssociated, len(release.issues))
 				}
 			}
-			// Also extract from referenced issues in the commit
-			for _, iss := range commit.Issues {
-				upperMatch := strings.ToUpper(iss)
-				if !seen[upperMatch] && re.MatchString(upperMatch) {
-					seen[upperMatch] = true
-					keys = append(keys, upperMatch)
+			if !bulk {
+				step := issueStep{
+					name:       "associate",
+					activity:   "associating issues",
+					failVerb:   "associating",
+					summaryKey: summaryIssuesAssociated,
+					succeeded: func(issueKey string) {
+						associated.add(issueKey, release.versionName)
+					},
+				}
+				var resp *plugin.ExecuteResponse
+				if resp, successCount = steps.run(ctx, step, release.issues, func(_ int, issueKey string) error {
+					issueClient := router.client(issueKey)
+					return p.withMovedIssue(ctx, issueClient, moved, issueKey, func(key string) error {
+						return p.associateIssueWithVersion(ctx, issueClient, key, cfg.versionFieldID(), release.versionName)
+					})
+				}); resp != nil {
+					return resp, nil
 				}
 			}
+			results = append(results, fmt.Sprintf("Associated %d/%d issues with %s version '%s'%s", successCount, len(release.issues), cfg.VersionField, release.versionName, scope))
 		}
 	}
+	// The primary project's version is reported at the top level
+	versionName = releases[0].versionName
+	versionID, versionURL, skipVersion := releases[0].versionID, releases[0].versionURL, releases[0].skipped
+	released := releasedCount > 0 && !releaseFailed
 
-	if changes != nil {
-		extractFromCommits(changes.Features)
-		extractFromCommits(changes.Fixes)
-		extractFromCommits(changes.Breaking)
-		extractFromCommits(changes.Performance)
-		extractFromCommits(changes.Refactor)
-		extractFromCommits(changes.Docs)
-		extractFromCommits(changes.Other)
+	// Label issues
+	label := ""
+	if cfg.AddLabels {
+		label = p.renderLabel(cfg, releaseCtx)
 	}
-
-	return keys
-}
-
-// createOrGetVersion creates a new version or returns existing one.
-func (p *JiraPlugin) createOrGetVersion(ctx context.Context, client *jira.Client, projectKey, versionName, description string) (*project.Version, error) {
-	// Try to find existing version first by listing project versions
-	versions, err := client.Project.ListProjectVersions(ctx, projectKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list project versions: %w", err)
+	if label != "" && modes.Associations && len(issueKeys) > 0 {
+		results = append(results, fmt.Sprintf("Would add label '%s' to %d issues", label, len(issueKeys)))
+	} else if label != "" && len(issueKeys) > 0 {
+		step := issueStep{name: "label", activity: "labeling issues", failVerb: "labeling", summaryKey: summaryIssuesLabeled}
+		resp, successCount := steps.run(ctx, step, issueKeys, func(_ int, issueKey string) error {
+			issueClient := router.client(issueKey)
+			return p.withMovedIssue(ctx, issueClient, moved, issueKey, func(key string) error {
+				return p.addLabel(ctx, issueClient, key, label)
+			})
+		})
+		if resp != nil {
+			return resp, nil
+		}
+		results = append(results, fmt.Sprintf("Added label '%s' to %d/%d issues", label, successCount, len(issueKeys)))
 	}
 
-	for _, v := range versions {
-		if v.Name == versionName {
-			return v, nil
+	// Set the component on issues
+	component := p.renderComponent(cfg, releaseCtx)
+	if component != "" && modes.Associations && len(issueKeys) > 0 {
+		results = append(results, fmt.Sprintf("Would set component '%s' on %d issues", component, len(issueKeys)))
+	} else if component != "" && len(issueKeys) > 0 {
+		step := issueStep{name: "component", activity: "setting components", failVerb: "setting the component on", summaryKey: summaryIssuesComponent}
+		resp, successCount := steps.run(ctx, step, issueKeys, func(_ int, issueKey string) error {
+			issueClient := router.client(issueKey)
+			return p.withMovedIssue(ctx, issueClient, moved, issueKey, func(key string) error {
+				return p.addComponent(ctx, issueClient, key, component)
+			})
+		})
+		if resp != nil {
+			return resp, nil
 		}
+		results = append(results, fmt.Sprintf("Set component '%s' on %d/%d issues", component, successCount, len(issueKeys)))
 	}
 
-	// Create new version using jirasdk
-	createdVersion, err := client.Project.CreateVersion(ctx, &project.CreateVersionInput{
-		Name:        versionName,
-		Description: description,
-		Project:     projectKey,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create version: %w", err)
+	// Check which issues are already done before transitions move them there
+	var closedIssues []string
+	commentsPosted := cfg.perIssueComments() && !modes.Comments && len(issueKeys) > 0
+	if !cfg.CommentOnClosed && commentsPosted {
+		closedIssues = p.doneIssues(ctx, router, issueKeys)
 	}
 
-	return createdVersion, nil
-}
-
-// releaseVersion marks a version as released.
-func (p *JiraPlugin) releaseVersion(ctx context.Context, client *jira.Client, versionID string) error {
-	now := time.Now().Format("2006-01-02")
-	released := true
-
-	_, err := client.Project.UpdateVersion(ctx, versionID, &project.UpdateVersionInput{
-		Released:    &released,
-		ReleaseDate: now,
-	})
-	return err
-}
-
-// associateIssueWithVersion adds a fix version to an issue.
-func (p *JiraPlugin) associateIssueWithVersion(ctx context.Context, client *jira.Client, issueKey, versionName string) error {
-	// Use jirasdk's Issue.Update with fixVersions field
-	return client.Issue.Update(ctx, issueKey, &issue.UpdateInput{
-		Fields: map[string]interface{}{
-			"fixVersions": []map[string]string{
-				{"name": versionName},
+	// Transition issues
+	var unavailableTransitions map[string]string
+	if cfg.TransitionIssues && cfg.transitionConfigured() && modes.Transitions && len(issueKeys) > 0 {
+		results = append(results, fmt.Sprintf("Would transition %d issues %s", len(issueKeys), cfg.transitionTarget()))
+	} else if cfg.TransitionIssues && cfg.transitionConfigured() && len(issueKeys) > 0 {
+		if cfg.SkipInvalidTransitions {
+			unavailableTransitions = map[string]string{}
+		}
+		step := issueStep{
+			name:       "transition",
+			activity:   "transitioning issues",
+			failVerb:   "transitioning",
+			summaryKey: summaryIssuesTransitioned,
+			record: func(_ int, issueKey string, err error) bool {
+				if !cfg.SkipInvalidTransitions || !errors.Is(err, errTransitionUnavailable) {
+					return false
+				}
+				skips.add(issueKey, "transition unavailable")
+				unavailableTransitions[issueKey] = err.Error()
+				return true
 			},
-		},
-	})
-}
-
-// transitionIssue transitions an issue to a specified status.
-func (p *JiraPlugin) transitionIssue(ctx context.Context, client *jira.Client, issueKey, transitionName string) error {
-	// Get available transitions for the issue
-	transitions, err := client.Workflow.GetTransitions(ctx, issueKey, nil)
-	if err != nil {
-		return fmt.Errorf("failed to get transitions: %w", err)
-	}
-
-	var transitionID string
-	lowerName := strings.ToLower(transitionName)
-	for _, t := range transitions {
-		if strings.ToLower(t.Name) == lowerName {
-			transitionID = t.ID
-			break
 		}
+		resp, successCount := steps.run(ctx, step, issueKeys, func(_ int, issueKey string) error {
+			issueClient := router.client(issueKey)
+			return p.withMovedIssue(ctx, issueClient, moved, issueKey, func(key string) error {
+				return p.transitionIssue(ctx, issueClient, key, cfg.transition())
+			})
+		})
+		if resp != nil {
+			return resp, nil
+		}
+		results = append(results, fmt.Sprintf("Transitioned %d/%d issues %s", successCount, len(issueKeys), cfg.transitionTarget()))
 	}
 
-	if transitionID == "" {
-		return fmt.Errorf("transition '%s' not found for issue %s", transitionName, issueKey)
+	// Add comments to issues
+	var comments issueComments
+	var commentErr error
+	var skippedComments []string
+	primaryIssue := ""
+	if commentsPosted {
+		comments, commentErr = p.renderIssueComments(cfg, released, releaseCtx)
 	}
-
-	// Perform the transition using jirasdk's Issue.DoTransition
-	return client.Issue.DoTransition(ctx, issueKey, &issue.TransitionInput{
-		Transition: &issue.Transition{ID: transitionID},
-	})
-}
-
-// addComment adds a comment to an issue.
-func (p *JiraPlugin) addComment(ctx context.Context, client *jira.Client, issueKey, body string) error {
-	// Create ADF (Atlassian Document Format) from plain text
-	adf := &issue.ADF{
-		Version: 1,
-		Type:    "doc",
-		Content: []issue.ADFNode{
-			{
-				Type: "paragraph",
-				Content: []issue.ADFNode{
-					{Type: "text", Text: body},
-				},
+	if cfg.perIssueComments() && modes.Comments && len(issueKeys) > 0 {
+		results = append(results, fmt.Sprintf("Would add comment to %d issues%s", len(issueKeys), cfg.commentVisibilityNote()))
+	} else if commentErr != nil {
+		results = append(results, fmt.Sprintf("Failed to render comment template: %v", commentErr))
+	} else if cfg.perIssueComments() && len(issueKeys) > 0 {
+		reverted := revertedIssues(cfg, releaseCtx.Changes)
+		breaking := indexBreakingChanges(cfg, releaseCtx.Changes)
+		siblings := siblingIssues(cfg, releaseCtx.Changes)
+		pulls := issuePullRequests(cfg, releaseCtx)
+		if cfg.IdempotentComments {
+			skippedComments = []string{}
+		}
+		var commentable []string
+		for _, issueKey := range issueKeys {
+			if !containsString(closedIssues, issueKey) {
+				commentable = append(commentable, issueKey)
+			}
+		}
+		primaryIssue = p.primaryIssue(ctx, cfg, router, commentable)
+		duplicates := make([]bool, len(issueKeys))
+		step := issueStep{
+			name:       "comment",
+			activity:   "commenting on issues",
+			failVerb:   "commenting on",
+			summaryKey: summaryCommentsAdded,
+			skip: func(issueKey string) bool {
+				return containsString(closedIssues, issueKey)
 			},
-		},
-	}
-	_, err := client.Issue.AddComment(ctx, issueKey, &issue.AddCommentInput{
-		Body: adf,
-	})
-	return err
-}
-
-// buildComment builds a comment from template.
-func (p *JiraPlugin) buildComment(template string, releaseCtx plugin.ReleaseContext) string {
-	comment := template
-	comment = strings.ReplaceAll(comment, "{version}", releaseCtx.Version)
-	comment = strings.ReplaceAll(comment, "{tag}", releaseCtx.TagName)
-	comment = strings.ReplaceAll(comment, "{release_url}", releaseCtx.RepositoryURL)
-	comment = strings.ReplaceAll(comment, "{repository}", releaseCtx.RepositoryName)
-	return comment
-}
+			record: func(i int, issueKey string, err error) bool {
+				if err != nil || !duplicates[i] {
+					return false
+				}
+				outcomes.succeed(issueKey)
+				skippedComments = append(skippedComments, issueKey)
+				return true
+			},
+		}
+		resp, successCount := steps.run(ctx, step, issueKeys, func(i int, issueKey string) error {
+			body := comments.status
+			switch {
+			case comments.revert != "" && reverted[issueKey]:
+				body = comments.revert
+			case issueKey == primaryIssue:
+				body = comments.primary
+			case breaking.only(issueKey):
+				body = comments.breaking
+			}
+			// {versions}, {breaking_notes}, {sibling_issues} and {pull_request} depend on this particular issue
+			body = strings.ReplaceAll(body, "{versions}", associated.list(issueKey))
+			body = strings.ReplaceAll(body, "{breaking_notes}", breaking.notesFor(issueKey))
+			body = strings.ReplaceAll(body, "{sibling_issues}", strings.Join(siblings[issueKey], ", "))
+			body = strings.ReplaceAll(body, "{pull_request}", strings.Join(pulls[issueKey], ", "))
+			body = strings.ReplaceAll(body, "{released}", releasedLabel(released))
+			issueClient := router.client(issueKey)
+			if strings.Contains(body, "{component}") {
+				// Components cost an extra request, so they are only fetched when the template uses them
+				body = strings.ReplaceAll(body, "{component}", p.issueComponents(ctx, issueClient, issueKey))
+			}
+			if cfg.NormalizeCommentUnicode {
+				body = normalizeUnicode(body)
+			}
+			if cfg.ThreadUnderRoot {
+				// Fall back to an unthreaded comment if the root cannot be resolved
+				if rootID, err := p.threadRoot(ctx, issueClient, issueKey, cfg.commentVisibility()); err == nil {
+					if link := threadCommentURL(router.baseURL(issueKey), issueKey, rootID); link != "" {
+						body = fmt.Sprintf("In reply to %s\n\n%s", link, body)
+					}
+				}
+			}
+			return p.withMovedIssue(ctx, issueClient, moved, issueKey, func(key string) error {
+				if cfg.IdempotentComments {
+					var err error
+					if duplicates[i], err = p.hasComment(ctx, issueClient, key, body, cfg.CommentFormat); err != nil || duplicates[i] {
+						return err
+					}
+				}
+				_, err := p.addComment(ctx, issueClient, key, body, cfg.CommentFormat, cfg.commentVisibility())
+				return err
+			})
+		})
+		if resp != nil {
+			return resp, nil
+		}
+		results = append(results, fmt.Sprintf("Added comments to %d/%d issues", successCount, len(issueKeys)-len(closedIssues)))
+		if len(closedIssues) > 0 {
+			results = append(results, fmt.Sprintf("Skipped comments on %d closed issues", len(closedIssues)))
+		}
+		if len(skippedComments) > 0 {
+			results = append(results, fmt.Sprintf("Skipped %d comments already posted", len(skippedComments)))
+		}
+	}
+
+	// Consolidate notifications into a single comment listing every issue
+	summaryComment := ""
+	if cfg.summaryComments() && len(issueKeys) > 0 {
+		if modes.Comments {
+			summaryComment = "planned"
+			results = append(results, fmt.Sprintf("Would add summary comment for %d issues to %s%s", len(issueKeys), cfg.SummaryCommentIssue, cfg.commentVisibilityNote()))
+		} else {
+			body := p.summaryComment(cfg, releaseCtx, versionName, issueKeys, released)
+			if cfg.NormalizeCommentUnicode {
+				body = normalizeUnicode(body)
+			}
+			if _, err := p.addComment(ctx, router.client(cfg.SummaryCommentIssue), cfg.SummaryCommentIssue, body, cfg.CommentFormat, cfg.commentVisibility()); err != nil {
+				summaryComment = "failed"
+				results = append(results, fmt.Sprintf("Failed to add summary comment to %s: %v", cfg.SummaryCommentIssue, err))
+			} else {
+				summaryComment = "posted"
+				results = append(results, fmt.Sprintf("Added summary comment for %d issues to %s", len(issueKeys), cfg.SummaryCommentIssue))
+			}
+		}
+	}
+
+	// Leave a note on the fallback issue when the release references no issues
+	noIssuesComment := ""
+	if cfg.NoIssuesComment != "" && totalIssues == 0 {
+		switch {
+		case cfg.NoIssuesIssue == "":
+			noIssuesComment = "skipped"
+			results = append(results, "No issues found; skipped no_issues_comment because no_issues_issue is not set")
+		case modes.Comments:
+			noIssuesComment = "planned"
+			results = append(results, fmt.Sprintf("Would add no-issues comment to %s%s", cfg.NoIssuesIssue, cfg.commentVisibilityNote()))
+		default:
+			body, err := p.renderComment(cfg, cfg.NoIssuesComment, releaseCtx)
+			if err != nil {
+				noIssuesComment = "failed"
+				results = append(results, fmt.Sprintf("Failed to render no_issues_comment: %v", err))
+				break
+			}
+			body = strings.ReplaceAll(body, "{released}", releasedLabel(released))
+			if cfg.NormalizeCommentUnicode {
+				body = normalizeUnicode(body)
+			}
+			if _, err := p.addComment(ctx, router.client(cfg.NoIssuesIssue), cfg.NoIssuesIssue, body, cfg.CommentFormat, cfg.commentVisibility()); err != nil {
+				noIssuesComment = "failed"
+				results = append(results, fmt.Sprintf("Failed to add no-issues comment to %s: %v", cfg.NoIssuesIssue, err))
+			} else {
+				noIssuesComment = "posted"
+				results = append(results, fmt.Sprintf("Added no-issues comment to %s", cfg.NoIssuesIssue))
+			}
+		}
+	}
+
+	// Report planned associations when that step ran in dry-run mode
+	issueVersionMap := associated
+	if cfg.AssociateIssues && modes.Associations {
+		issueVersionMap = planned
+	}
+
+	outputs := map[string]any{
+		"version_name":       versionName,
+		"version_id":         versionID,
+		"version_url":        versionURL,
+		"project_key":        cfg.ProjectKey,
+		"issues":             issueKeys,
+		"issue_version_map":  map[string][]string(issueVersionMap),
+		"credential_sources": resolveCredentials(cfg).sources(),
+		"version_skipped":    skipVersion,
+	}
+	if len(cfg.ProjectKeys) > 0 {
+		outputs["projects"] = projectOutputs(releases)
+	}
+	if cfg.SkipClosedBoardIssues {
+		outputs["closed_sprint_issues"] = closedSprintIssues
+	}
+	if cfg.FollowMovedIssues {
+		outputs["moved_issues"] = moved.keys
+	}
+	if snapshot != nil {
+		outputs["snapshot"] = snapshot
+	}
+	if len(cfg.InstanceKeyMap) > 0 {
+		outputs["unmapped_issues"] = unmappedIssues
+	}
+	if inferredProject {
+		outputs["project_key_inferred"] = true
+	}
+	if len(keyWarnings) > 0 {
+		outputs["key_warnings"] = keyWarnings
+	}
+	if keywords != nil {
+		outputs["issue_keywords"] = keywords
+	}
+	if !cfg.CommentOnClosed && commentsPosted {
+		outputs["closed_issues"] = closedIssues
+	}
+	if skippedComments != nil {
+		outputs["skipped_comments"] = skippedComments
+	}
+	if unavailableTransitions != nil {
+		outputs["unavailable_transitions"] = unavailableTransitions
+	}
+	if bulkFallbacks != nil {
+		outputs["bulk_fallback"] = bulkFallbacks
+	}
+	if label != "" {
+		outputs["label"] = label
+	}
+	if component != "" {
+		outputs["component"] = component
+	}
+	if cfg.ArchivePreviousVersion {
+		outputs["archived_version"] = releases[0].archivedVersion
+	}
+	if date != "" {
+		outputs["release_date"] = date
+	}
+	if start := cfg.versionStartDate(); start != "" {
+		outputs["start_date"] = start
+	}
+	if primaryIssue != "" {
+		outputs["primary_issue"] = primaryIssue
+	}
+	if noIssuesComment != "" {
+		outputs["no_issues_comment"] = noIssuesComment
+	}
+	if summaryComment != "" {
+		outputs["summary_comment"] = summaryComment
+	}
+	if skips.count() > 0 {
+		results = append(results, skips.summary(totalIssues))
+		if cfg.VerboseMessage {
+			results = append(results, "Skipped issues: "+strings.Join(skips.details(), ", "))
+		}
+		outputs["skipped_issues"] = skips.reasons
+	}
+	for name, value := range outcomes.outputs() {
+		outputs[name] = value
+	}
+
+	summary.finish()
+
+	resp := &plugin.ExecuteResponse{
+		Success: true,
+		Message: strings.Join(results, "; "),
+		Outputs: outputs,
+	}
+	if failed := outcomes.failed(); len(failed) > 0 {
+		resp.Success = false
+		resp.Error = fmt.Sprintf("%d/%d issues had failed operations: %s", len(failed), len(outcomes.keys), strings.Join(failed, ", "))
+	}
+	return resp, nil
+}
+
+// handleOnSuccess handles the OnSuccess hook - report the release summary and, with
+// comment_on_success, comment on every issue once all publishers have finished.
+func (p *JiraPlugin) handleOnSuccess(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	outputs := map[string]any{
+		"release_summary": p.releaseSummaryOutput(cfg, releaseCtx),
+	}
+	if !cfg.CommentOnSuccess {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Release successful - Jira integration acknowledged",
+			Outputs: outputs,
+		}, nil
+	}
+
+	issueKeys, unmappedIssues := routeIssues(cfg, p.extractIssueKeys(cfg, releaseCtx.Changes))
+	if issueKeys == nil {
+		issueKeys = []string{}
+	}
+	outputs["issues"] = issueKeys
+	if len(cfg.InstanceKeyMap) > 0 {
+		outputs["unmapped_issues"] = unmappedIssues
+	}
+	if len(issueKeys) == 0 {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Release successful - no Jira issues to comment on",
+			Outputs: outputs,
+		}, nil
+	}
+
+	body, err := p.renderComment(cfg, cfg.SuccessCommentTemplate, releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to render success_comment_template: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+	if cfg.NormalizeCommentUnicode {
+		body = normalizeUnicode(body)
+	}
+	if cfg.dryRunModes(dryRun).Comments {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: fmt.Sprintf("Release successful - would add success comment to %d issues%s", len(issueKeys), cfg.commentVisibilityNote()),
+			Outputs: outputs,
+		}, nil
+	}
+
+	router, err := p.hookRouter(cfg, releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+			Outputs: outputs,
+		}, nil
+	}
+
+	outcomes := newIssueOutcomes()
+	skips := newIssueSkips()
+	successCount := 0
+	var skippedComments []string
+	errs := notStarted(len(issueKeys))
+	for i, issueKey := range issueKeys {
+		issueClient := router.client(issueKey)
+		duplicate := false
+		var err error
+		if cfg.IdempotentComments {
+			duplicate, err = p.hasComment(ctx, issueClient, issueKey, body, cfg.CommentFormat)
+		}
+		if err == nil && !duplicate {
+			_, err = p.addComment(ctx, issueClient, issueKey, body, cfg.CommentFormat, cfg.commentVisibility())
+		}
+		errs[i] = err
+		switch {
+		case errors.Is(err, errCredentialsExpired):
+			return credentialsExpiredResponse("commenting on issues", issueKeys, errs, outcomes), nil
+		case err == nil && duplicate:
+			outcomes.succeed(issueKey)
+			skippedComments = append(skippedComments, issueKey)
+		case err == nil:
+			outcomes.succeed(issueKey)
+			successCount++
+		case isNotFound(err):
+			skips.add(issueKey, "missing")
+		default:
+			outcomes.fail(issueKey, "success comment", err)
+			if cfg.FailFast {
+				return failFastResponse("commenting on", issueKey, err, outcomes), nil
+			}
+		}
+	}
+
+	results := []string{fmt.Sprintf("Release successful - added success comment to %d/%d issues", successCount, len(issueKeys))}
+	if len(skippedComments) > 0 {
+		results = append(results, fmt.Sprintf("Skipped %d comments already posted", len(skippedComments)))
+		outputs["skipped_comments"] = skippedComments
+	}
+	if skips.count() > 0 {
+		results = append(results, skips.summary(len(issueKeys)))
+		outputs["skipped_issues"] = skips.reasons
+	}
+	for name, value := range outcomes.outputs() {
+		outputs[name] = value
+	}
+
+	resp := &plugin.ExecuteResponse{
+		Success: true,
+		Message: strings.Join(results, "; "),
+		Outputs: outputs,
+	}
+	if failed := outcomes.failed(); len(failed) > 0 {
+		resp.Success = false
+		resp.Error = fmt.Sprintf("%d/%d issues had failed operations: %s", len(failed), len(outcomes.keys), strings.Join(failed, ", "))
+	}
+	return resp, nil
+}
+
+// defaultErrorComment is posted by transition_on_error when error_comment_template is not set.
+// The SDK passes no failure details to OnError, so the comment cannot include the error. It
+// does not claim the issue was reopened: it is also posted when the transition is unavailable
+// or only comments are live under dry_run_transitions.
+const defaultErrorComment = "Release {version} failed."
+
+// errorComment returns the comment template of transition_on_error.
+func (c *Config) errorComment() string {
+	if strings.TrimSpace(c.ErrorCommentTemplate) != "" {
+		return c.ErrorCommentTemplate
+	}
+	return defaultErrorComment
+}
+
+// handleOnError handles the OnError hook - report the release summary and, with
+// transition_on_error, move the release's issues with error_transition_name and comment on
+// them so the failed release can be followed up.
+func (p *JiraPlugin) handleOnError(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	outputs := map[string]any{
+		"release_summary": p.releaseSummaryOutput(cfg, releaseCtx),
+	}
+	if !cfg.TransitionOnError {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Release failed - Jira integration acknowledged",
+			Outputs: outputs,
+		}, nil
+	}
+
+	issueKeys, unmappedIssues := routeIssues(cfg, p.extractIssueKeys(cfg, releaseCtx.Changes))
+	if issueKeys == nil {
+		issueKeys = []string{}
+	}
+	outputs["issues"] = issueKeys
+	if len(cfg.InstanceKeyMap) > 0 {
+		outputs["unmapped_issues"] = unmappedIssues
+	}
+	if len(issueKeys) == 0 {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Release failed - no Jira issues to roll back",
+			Outputs: outputs,
+		}, nil
+	}
+
+	body, err := p.renderComment(cfg, cfg.errorComment(), releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to render error_comment_template: %v", err),
+			Outputs: outputs,
+		}, nil
+	}
+	if cfg.NormalizeCommentUnicode {
+		body = normalizeUnicode(body)
+	}
+
+	modes := cfg.dryRunModes(dryRun)
+	results := []string{}
+	if modes.Transitions {
+		results = append(results, fmt.Sprintf("Would transition %d issues to '%s'", len(issueKeys), cfg.ErrorTransitionName))
+	}
+	if modes.Comments {
+		results = append(results, fmt.Sprintf("Would add failure comment to %d issues%s", len(issueKeys), cfg.commentVisibilityNote()))
+	}
+	if modes.Transitions && modes.Comments {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Release failed - " + strings.Join(results, "; "),
+			Outputs: outputs,
+		}, nil
+	}
+
+	router, err := p.hookRouter(cfg, releaseCtx)
+	if err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+			Outputs: outputs,
+		}, nil
+	}
+
+	outcomes := newIssueOutcomes()
+	skips := newIssueSkips()
+	reopened := []string{}
+	// Issues left in their status still get the comment, so they are only skipped without one
+	notReopened := []string{}
+	commentCount := 0
+	errs := notStarted(len(issueKeys))
+	for i, issueKey := range issueKeys {
+		issueClient := router.client(issueKey)
+		errs[i] = nil
+		if !modes.Transitions {
+			err := p.transitionIssue(ctx, issueClient, issueKey, transitionSpec{Name: cfg.ErrorTransitionName})
+			switch {
+			case errors.Is(err, errCredentialsExpired):
+				errs[i] = err
+				return credentialsExpiredResponse("rolling back issues", issueKeys, errs, outcomes), nil
+			case err == nil:
+				reopened = append(reopened, issueKey)
+			case isNotFound(err):
+				errs[i] = err
+				skips.add(issueKey, "missing")
+				continue
+			case errors.Is(err, errTransitionUnavailable):
+				// Issues the release never moved cannot be rolled back, but still get the note
+				notReopened = append(notReopened, issueKey)
+				if modes.Comments {
+					skips.add(issueKey, "transition unavailable")
+					continue
+				}
+			default:
+				errs[i] = err
+				outcomes.fail(issueKey, "error transition", err)
+				if cfg.FailFast {
+					return failFastResponse("rolling back", issueKey, err, outcomes), nil
+				}
+				continue
+			}
+		}
+
+		if !modes.Comments {
+			duplicate := false
+			var err error
+			if cfg.IdempotentComments {
+				duplicate, err = p.hasComment(ctx, issueClient, issueKey, body, cfg.CommentFormat)
+			}
+			if err == nil && !duplicate {
+				_, err = p.addComment(ctx, issueClient, issueKey, body, cfg.CommentFormat, cfg.commentVisibility())
+			}
+			if err != nil {
+				errs[i] = err
+			}
+			switch {
+			case errors.Is(err, errCredentialsExpired):
+				return credentialsExpiredResponse("commenting on issues", issueKeys, errs, outcomes), nil
+			case err == nil && !duplicate:
+				commentCount++
+			case err != nil:
+				outcomes.fail(issueKey, "error comment", err)
+				if cfg.FailFast {
+					return failFastResponse("commenting on", issueKey, err, outcomes), nil
+				}
+				continue
+			}
+		}
+		outcomes.succeed(issueKey)
+	}
+
+	if !modes.Transitions {
+		results = append(results, fmt.Sprintf("Transitioned %d/%d issues to '%s'", len(reopened), len(issueKeys), cfg.ErrorTransitionName))
+		outputs["reopened_issues"] = reopened
+		outputs["not_reopened_issues"] = notReopened
+	}
+	if !modes.Comments {
+		results = append(results, fmt.Sprintf("Added failure comments to %d/%d issues", commentCount, len(issueKeys)))
+	}
+	if skips.count() > 0 {
+		results = append(results, skips.summary(len(issueKeys)))
+		outputs["skipped_issues"] = skips.reasons
+	}
+	for name, value := range outcomes.outputs() {
+		outputs[name] = value
+	}
+
+	resp := &plugin.ExecuteResponse{
+		Success: true,
+		Message: "Release failed - " + strings.Join(results, "; "),
+		Outputs: outputs,
+	}
+	if failed := outcomes.failed(); len(failed) > 0 {
+		resp.Success = false
+		resp.Error = fmt.Sprintf("%d/%d issues had failed operations: %s", len(failed), len(outcomes.keys), strings.Join(failed, ", "))
+	}
+	return resp, nil
+}
+
+// hookRouter creates the Jira clients used by the OnSuccess and OnError hooks.
+func (p *JiraPlugin) hookRouter(cfg *Config, releaseCtx plugin.ReleaseContext) (issueRouter, error) {
+	releaseHeader := releaseHeaderMiddleware(releaseCtx.Version)
+	limiter := newRequestLimiter(cfg.RequestsPerSecond)
+	client, err := p.getClient(cfg, limiter, releaseHeader)
+	if err != nil {
+		return issueRouter{}, fmt.Errorf("failed to create Jira client: %w", err)
+	}
+	return p.newIssueRouter(cfg, client, limiter, releaseHeader)
+}
+
+// jiraVersionName returns the name of the Jira version for a release.
+func (c *Config) jiraVersionName(releaseCtx plugin.ReleaseContext) string {
+	versionName := c.VersionName
+	if versionName == "" {
+		versionName = releaseCtx.Version
+	}
+	if c.StripVersionPrefix && len(versionName) > 1 && (versionName[0] == 'v' || versionName[0] == 'V') {
+		versionName = versionName[1:]
+	}
+	return versionName
+}
+
+// errorCodeNoIssues is reported in the error_code output when require_issues finds no issues.
+const errorCodeNoIssues = "no_issues"
+
+// noIssuesResponse fails a release that references no Jira issues while require_issues is set.
+func noIssuesResponse(cfg *Config) *plugin.ExecuteResponse {
+	source := "in the release's commits"
+	if cfg.IssueSource == issueSourceJQL {
+		source = "with jql_query"
+	}
+	return &plugin.ExecuteResponse{
+		Success: false,
+		Error:   fmt.Sprintf("no Jira issues found %s and require_issues is enabled", source),
+		Outputs: map[string]any{
+			"error_code": errorCodeNoIssues,
+			"issues":     []string{},
+		},
+	}
+}
+
+// credentialsExpiredResponse fails the run when credentials expire during a per-issue step.
+// errs holds each issue's result in the step: issues that succeeded are reported as completed,
+// and those never started, cut off by the expiry or canceled as remaining so the release can be
+// resumed. Results already recorded in outcomes are included.
+func credentialsExpiredResponse(step string, issueKeys []string, errs []error, outcomes *issueOutcomes) *plugin.ExecuteResponse {
+	completed, remaining := []string{}, []string{}
+	for i, issueKey := range issueKeys {
+		if errs[i] == nil {
+			completed = append(completed, issueKey)
+		} else if unfinished(errs[i]) {
+			remaining = append(remaining, issueKey)
+		}
+	}
+	outputs := outcomes.outputs()
+	outputs["failed_step"] = step
+	outputs["completed_issues"] = completed
+	outputs["remaining_issues"] = remaining
+	return &plugin.ExecuteResponse{
+		Success: false,
+		Error: fmt.Sprintf("%v while %s: %d/%d issues completed, %d remaining (%s); refresh the token and re-run the release",
+			errCredentialsExpired, step, len(completed), len(issueKeys), len(remaining), strings.Join(remaining, ", ")),
+		Outputs: outputs,
+	}
+}
+
+// unfinished reports whether an issue's result means its operation still has to be done:
+// it was never started, was cut off by expired credentials or was canceled.
+func unfinished(err error) bool {
+	return errors.Is(err, errNotStarted) || errors.Is(err, errCredentialsExpired) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// failFastResponse aborts the run at the first failed issue operation when fail_fast is enabled.
+func failFastResponse(step, issueKey string, err error, outcomes *issueOutcomes) *plugin.ExecuteResponse {
+	return &plugin.ExecuteResponse{
+		Success: false,
+		Error:   fmt.Sprintf("failed %s %s: %v (fail_fast is enabled)", step, issueKey, err),
+		Outputs: outcomes.outputs(),
+	}
+}
+
+// issueStep describes a per-issue PostPublish step for issueSteps.run.
+type issueStep struct {
+	name       string // step recorded in issue_errors, e.g. "label"
+	activity   string // reported when credentials expire, e.g. "labeling issues"
+	failVerb   string // reported when fail_fast stops the run, e.g. "labeling"
+	summaryKey string // release summary action counted for each success
+
+	// skip, if set, leaves an issue out of the step entirely.
+	skip func(issueKey string) bool
+	// record, if set, records an issue's result itself and reports whether it did, for
+	// results that are neither a plain success nor a failure.
+	record func(i int, issueKey string, err error) bool
+	// succeeded, if set, is called for each issue the step succeeded on.
+	succeeded func(issueKey string)
+}
+
+// issueSteps runs the per-issue PostPublish steps and records their results.
+type issueSteps struct {
+	cfg      *Config
+	outcomes *issueOutcomes
+	skips    *issueSkips
+	summary  *releaseSummary
+	stop     func(err error) bool
+}
+
+// run applies op to each issue and records the results in issue order. It returns a
+// response ending the run when credentials expire or fail_fast stops at a failure, and
+// the number of issues op succeeded on. When credentials expire, the results of issues
+// that finished are recorded before the run ends, since with concurrency they may include
+// issues after the one that hit the expiry.
+func (s *issueSteps) run(ctx context.Context, step issueStep, issueKeys []string, op func(i int, issueKey string) error) (*plugin.ExecuteResponse, int) {
+	errs := forEachIssue(ctx, s.cfg.concurrency(), issueKeys, func(i int, issueKey string) error {
+		if step.skip != nil && step.skip(issueKey) {
+			return nil
+		}
+		return op(i, issueKey)
+	}, s.stop)
+	expired := slices.ContainsFunc(errs, func(err error) bool {
+		return errors.Is(err, errCredentialsExpired)
+	})
+
+	successCount := 0
+	for i, issueKey := range issueKeys {
+		if step.skip != nil && step.skip(issueKey) {
+			continue
+		}
+		err := errs[i]
+		if expired && unfinished(err) {
+			// Left for the re-run once the token is refreshed
+			continue
+		}
+		if step.record != nil && step.record(i, issueKey, err) {
+			continue
+		}
+		if err == nil {
+			if step.succeeded != nil {
+				step.succeeded(issueKey)
+			}
+			s.outcomes.succeed(issueKey)
+			successCount++
+			s.summary.add(step.summaryKey, 1)
+		} else if isNotFound(err) {
+			s.skips.add(issueKey, "missing")
+		} else {
+			s.outcomes.fail(issueKey, step.name, err)
+			if s.cfg.FailFast && !expired {
+				return failFastResponse(step.failVerb, issueKey, err, s.outcomes), successCount
+			}
+		}
+	}
+	if expired {
+		return credentialsExpiredResponse(step.activity, issueKeys, errs, s.outcomes), successCount
+	}
+	return nil, successCount
+}
+
+// planPostPublish describes the PostPublish actions without performing any writes.
+func (p *JiraPlugin) planPostPublish(ctx context.Context, cfg *Config, client *jira.Client, versionName string, issueKeys []string, label, component string) (*plugin.ExecuteResponse, error) {
+	actions := []string{}
+	// Actions whose outcome depends on Jira data are flagged so reviewers know the plan is not final
+	needsConnectivity := map[string]bool{}
+	plan := func(action string, connectivity bool) {
+		actions = append(actions, action)
+		if connectivity {
+			needsConnectivity[action] = true
+		}
+	}
+	// The structured plan lists each write per target for tooling that renders or diffs plans
+	steps := []plannedAction{}
+	step := func(actionType, detail string, targets ...string) {
+		for _, target := range targets {
+			steps = append(steps, plannedAction{Type: actionType, Target: target, Detail: detail})
+		}
+	}
+	// The Jira clock is unknown while planning, so the local date is shown
+	date := cfg.versionReleaseDate(nil)
+	releases := projectReleases(cfg, issueKeys, versionName)
+	for _, release := range releases {
+		scope := release.scope(cfg)
+		if release.skipped {
+			actions = append(actions, fmt.Sprintf("Skip version '%s'%s (no issues to associate)", versionName, scope))
+			step("skip_version", versionName, release.projectKey)
+		} else if cfg.CreateVersion && cfg.OnExistingVersion == existingVersionSuffix {
+			plan(fmt.Sprintf("Create version '%s' in project %s%s, suffixed if the name is taken", versionName, release.projectKey, cfg.startDateNote()), true)
+			step("create_version", versionName, release.projectKey)
+		} else if cfg.CreateVersion {
+			actions = append(actions, fmt.Sprintf("Create version '%s' in project %s%s", versionName, release.projectKey, cfg.startDateNote()))
+			step("create_version", versionName, release.projectKey)
+		}
+		if cfg.ReleaseVersion && !release.skipped {
+			actions = append(actions, fmt.Sprintf("Mark version '%s'%s as released on %s", versionName, scope, date))
+			step("release_version", versionName, release.projectKey)
+		}
+		if cfg.ArchivePreviousVersion && cfg.ReleaseVersion && !release.skipped {
+			if cfg.DryRunVerify || cfg.ReadOnly {
+				previous, err := p.previousVersion(ctx, client, release.projectKey, versionName)
+				if err != nil {
+					return &plugin.ExecuteResponse{
+						Success: false,
+						Error:   fmt.Sprintf("dry run verification failed: %v", err),
+					}, nil
+				}
+				if previous != nil {
+					actions = append(actions, fmt.Sprintf("Archive version '%s'%s", previous.Name, scope))
+					step("archive_version", previous.Name, release.projectKey)
+				}
+			} else {
+				plan(fmt.Sprintf("Archive the previous released version%s", scope), true)
+				step("archive_version", "previous released version", release.projectKey)
+			}
+		}
+		if cfg.AssociateIssues && len(release.issues) > 0 {
+			actions = append(actions, fmt.Sprintf("Associate %d issues with %s version '%s'%s", len(release.issues), cfg.VersionField, versionName, scope))
+			step("associate", versionName, release.issues...)
+		}
+	}
+	if cfg.AddLabels && label != "" && len(issueKeys) > 0 {
+		plan(fmt.Sprintf("Add label '%s' to %d issues", label, len(issueKeys)), false)
+		step("label", label, issueKeys...)
+	}
+	if component != "" && len(issueKeys) > 0 {
+		// Whether the component exists is only known once Jira is asked
+		plan(fmt.Sprintf("Set component '%s' on %d issues", component, len(issueKeys)), true)
+		step("component", component, issueKeys...)
+	}
+	var resolvedTransitions map[string]string
+	transitionNote := ""
+	if cfg.TransitionIssues && cfg.transitionConfigured() && len(issueKeys) > 0 {
+		action := fmt.Sprintf("Transition %d issues %s", len(issueKeys), cfg.transitionTarget())
+		if cfg.DryRunVerify || cfg.ReadOnly {
+			// Resolve transitions against a sample issue; workflows are usually shared per project
+			transitions, err := p.getTransitions(ctx, client, issueKeys[0])
+			if err != nil {
+				return &plugin.ExecuteResponse{
+					Success: false,
+					Error:   fmt.Sprintf("dry run verification failed: %v", err),
+				}, nil
+			}
+			resolvedTransitions = transitionIDsByName(transitions)
+			switch id := findTransitionID(transitions, cfg.TransitionName); {
+			case cfg.TransitionID != "":
+				if !hasTransitionID(transitions, cfg.TransitionID) {
+					action += fmt.Sprintf(" (transition not available for %s)", issueKeys[0])
+				}
+			case id != "":
+				action += fmt.Sprintf(" (transition ID %s)", id)
+			default:
+				action += fmt.Sprintf(" (transition not available for %s)", issueKeys[0])
+			}
+		} else if cfg.TransitionID == "" {
+			transitionNote = "Transition IDs cannot be resolved offline; enable dry_run_verify to resolve them"
+		}
+		plan(action, resolvedTransitions == nil && cfg.TransitionID == "")
+		detail := cfg.TransitionName
+		if cfg.TransitionID != "" {
+			detail = "transition ID " + cfg.TransitionID
+		}
+		step("transition", detail, issueKeys...)
+	}
+	if cfg.perIssueComments() && len(issueKeys) > 0 {
+		// {component} is read from each issue when the comment is posted
+		plan(fmt.Sprintf("Add comment to %d issues%s", len(issueKeys), cfg.commentVisibilityNote()), cfg.commentsUse("{component}"))
+		step("comment", cfg.statusCommentTemplate(cfg.ReleaseVersion), issueKeys...)
+	}
+	if cfg.summaryComments() && len(issueKeys) > 0 {
+		actions = append(actions, fmt.Sprintf("Add summary comment for %d issues to %s%s", len(issueKeys), cfg.SummaryCommentIssue, cfg.commentVisibilityNote()))
+		step("summary_comment", fmt.Sprintf("%d issues", len(issueKeys)), cfg.SummaryCommentIssue)
+	}
+	if cfg.NoIssuesComment != "" && cfg.NoIssuesIssue != "" && len(issueKeys) == 0 {
+		actions = append(actions, fmt.Sprintf("Add no-issues comment to %s%s", cfg.NoIssuesIssue, cfg.commentVisibilityNote()))
+		step("no_issues_comment", cfg.NoIssuesComment, cfg.NoIssuesIssue)
+	}
+	if cfg.SkipClosedBoardIssues && len(issueKeys) > 0 {
+		plan("Skip issues in closed sprints (checked at publish time)", true)
+	}
+	if !cfg.CommentOnClosed && cfg.perIssueComments() && len(issueKeys) > 0 {
+		plan("Skip comments on issues already done (checked at publish time)", true)
+	}
+
+	issueVersionMap := issueVersions{}
+	if cfg.AssociateIssues {
+		issueVersionMap = plannedIssueVersions(issueKeys, versionName)
+	}
+
+	outputs := map[string]any{
+		"version_name":       versionName,
+		"version_id":         "",
+		"version_url":        "",
+		"project_key":        cfg.ProjectKey,
+		"issues":             issueKeys,
+		"actions":            actions,
+		"plan":               steps,
+		"credential_sources": resolveCredentials(cfg).sources(),
+		"issue_version_map":  map[string][]string(issueVersionMap),
+		"version_skipped":    releases[0].skipped,
+	}
+	if cfg.ReleaseVersion {
+		outputs["release_date"] = date
+	}
+	if start := cfg.versionStartDate(); start != "" {
+		outputs["start_date"] = start
+	}
+	if len(cfg.ProjectKeys) > 0 {
+		outputs["projects"] = projectOutputs(releases)
+	}
+	if resolvedTransitions != nil {
+		outputs["resolved_transitions"] = resolvedTransitions
+	}
+	if transitionNote != "" {
+		outputs["resolved_transitions_note"] = transitionNote
+	}
+	if cfg.TransitionIssues && cfg.transitionConfigured() && cfg.TransitionResolution != "" && len(issueKeys) > 0 {
+		outputs["transition_resolution"] = cfg.TransitionResolution
+	}
+
+	configOnly, connectivity := []string{}, []string{}
+	annotated := make([]string, len(actions))
+	for i, action := range actions {
+		annotated[i] = action
+		if needsConnectivity[action] {
+			connectivity = append(connectivity, action)
+			annotated[i] += " [requires connectivity to confirm]"
+		} else {
+			configOnly = append(configOnly, action)
+		}
+	}
+	outputs["config_only_actions"] = configOnly
+	outputs["connectivity_actions"] = connectivity
+
+	message := fmt.Sprintf("Would perform: %s", strings.Join(annotated, "; "))
+	if cfg.ReadOnly {
+		outputs["read_only"] = true
+		message = "Read-only mode, no changes made. " + message
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+		Outputs: outputs,
+	}, nil
+}
+
+// projectRelease is the release's version in one project.
+type projectRelease struct {
+	projectKey  string
+	issues      []string
+	versionName string
+	versionID   string
+	versionURL  string
+	skipped     bool
+	released    bool
+	// archivedVersion is the name of the previous version archived after the release.
+	archivedVersion string
+}
+
+// projectReleases splits the release across its projects, grouping issues by key prefix.
+// With a single project every issue belongs to it, whatever its prefix.
+func projectReleases(cfg *Config, issueKeys []string, versionName string) []*projectRelease {
+	releases := make([]*projectRelease, 0, len(cfg.projects()))
+	byKey := make(map[string]*projectRelease, len(cfg.projects()))
+	for _, projectKey := range cfg.projects() {
+		release := &projectRelease{projectKey: projectKey, issues: []string{}, versionName: versionName}
+		releases = append(releases, release)
+		byKey[projectKey] = release
+	}
+	for _, issueKey := range issueKeys {
+		release := releases[0]
+		if projectKey, ok := cfg.configuredProject(issuePrefix(issueKey)); ok && cfg.multiProject() {
+			release = byKey[projectKey]
+		}
+		release.issues = append(release.issues, issueKey)
+	}
+	for _, release := range releases {
+		release.skipped = cfg.skipVersionCreation(release.issues)
+	}
+	return releases
+}
+
+// scope names the project in messages when the release spans several projects.
+func (r *projectRelease) scope(cfg *Config) string {
+	if !cfg.multiProject() {
+		return ""
+	}
+	return " in project " + r.projectKey
+}
+
+// projectOutputs reports the issues and version of each project.
+func projectOutputs(releases []*projectRelease) map[string]any {
+	outputs := make(map[string]any, len(releases))
+	for _, release := range releases {
+		outputs[release.projectKey] = map[string]any{
+			"issues":           release.issues,
+			"version_name":     release.versionName,
+			"version_id":       release.versionID,
+			"version_url":      release.versionURL,
+			"version_skipped":  release.skipped,
+			"released":         release.released,
+			"archived_version": release.archivedVersion,
+		}
+	}
+	return outputs
+}
+
+// plannedAction is one write of a dry-run plan, such as transitioning an issue.
+type plannedAction struct {
+	// Type is the kind of write: create_version, skip_version, release_version, archive_version,
+	// associate, label, component, transition, comment, summary_comment or no_issues_comment.
+	Type string `json:"type"`
+	// Target is the project or issue key the action applies to.
+	Target string `json:"target"`
+	// Detail is the version name, transition or comment template involved.
+	Detail string `json:"detail"`
+}
+
+// issueVersions tracks the versions each issue was associated with during a run.
+type issueVersions map[string][]string
+
+// plannedIssueVersions maps every issue to the version it would be associated with.
+func plannedIssueVersions(issueKeys []string, versionName string) issueVersions {
+	iv := issueVersions{}
+	for _, issueKey := range issueKeys {
+		iv.add(issueKey, versionName)
+	}
+	return iv
+}
+
+// add records that issueKey was associated with versionName.
+func (iv issueVersions) add(issueKey, versionName string) {
+	for _, v := range iv[issueKey] {
+		if v == versionName {
+			return
+		}
+	}
+	iv[issueKey] = append(iv[issueKey], versionName)
+}
+
+// list returns the versions associated with issueKey as a comma-separated string.
+func (iv issueVersions) list(issueKey string) string {
+	return strings.Join(iv[issueKey], ", ")
+}
+
+// extractIssueKeys extracts Jira issue keys from commit messages.
+func (p *JiraPlugin) extractIssueKeys(cfg *Config, changes *plugin.CategorizedChanges) []string {
+	re, err := issueKeyPattern(cfg)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+
+	commits := issueCommits(cfg, changes)
+	ignored := 0
+	for _, commitKeys := range commitsIssueKeys(cfg, re, commits) {
+		if cfg.tooManyKeys(commitKeys) {
+			ignored++
+			continue
+		}
+		for _, key := range commitKeys {
+			// With project_keys, keys from other projects are not part of the release
+			if _, ok := cfg.configuredProject(issuePrefix(key)); len(cfg.ProjectKeys) > 0 && !ok {
+				continue
+			}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	cfg.logger().Debug("extracted issue keys", "commits", len(commits), "issues", len(keys), "ignored_commits", ignored)
+	return keys
+}
+
+// tooManyKeys reports whether a commit's keys exceed max_keys_per_commit.
+func (c *Config) tooManyKeys(keys []string) bool {
+	if c.MaxKeysPerCommit <= 0 {
+		return false
+	}
+	return distinctCount(keys) > c.MaxKeysPerCommit
+}
+
+// distinctCount returns the number of distinct values in values.
+func distinctCount(values []string) int {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		seen[v] = true
+	}
+	return len(seen)
+}
+
+// keyLimitWarnings describes each commit whose keys were ignored for exceeding
+// max_keys_per_commit.
+func keyLimitWarnings(cfg *Config, changes *plugin.CategorizedChanges) []string {
+	re, err := issueKeyPattern(cfg)
+	if err != nil || cfg.MaxKeysPerCommit <= 0 {
+		return nil
+	}
+
+	var warnings []string
+	commits := issueCommits(cfg, changes)
+	for i, keys := range commitsIssueKeys(cfg, re, commits) {
+		commit := commits[i]
+		if !cfg.tooManyKeys(keys) {
+			continue
+		}
+		commitRef := commit.Description
+		if commit.Hash != "" {
+			commitRef = commit.Hash[:min(len(commit.Hash), 7)]
+		}
+		warnings = append(warnings, fmt.Sprintf("commit %s references %d issue keys (max_keys_per_commit is %d); its keys were ignored",
+			commitRef, distinctCount(keys), cfg.MaxKeysPerCommit))
+	}
+	return warnings
+}
+
+// issueKeyPattern compiles the configured issue key patterns, or the default one.
+func issueKeyPattern(cfg *Config) (issueKeyPatterns, error) {
+	patterns := cfg.issuePatterns()
+	if len(patterns) == 0 {
+		patterns = []string{defaultIssuePattern}
+	}
+	compiled := make(issueKeyPatterns, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// issuePatterns returns issue_pattern merged with issue_patterns.
+func (c *Config) issuePatterns() []string {
+	var patterns []string
+	if c.IssuePattern != "" {
+		patterns = append(patterns, c.IssuePattern)
+	}
+	for _, pattern := range c.IssuePatterns {
+		if !containsString(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// issueKeyPatterns matches issue keys with any of several patterns.
+type issueKeyPatterns []*regexp.Regexp
+
+// findAll returns the keys matched by any pattern in order of appearance. A match with project
+// and number groups yields "PROJECT-NUMBER", so notations such as #PROJ/123 become regular keys.
+func (ps issueKeyPatterns) findAll(text string) []string {
+	type match struct {
+		start int
+		key   string
+	}
+	var matches []match
+	for _, re := range ps {
+		project, number := re.SubexpIndex("project"), re.SubexpIndex("number")
+		for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+			key := text[loc[0]:loc[1]]
+			if project > 0 && number > 0 && loc[2*project] >= 0 && loc[2*number] >= 0 {
+				key = text[loc[2*project]:loc[2*project+1]] + "-" + text[loc[2*number]:loc[2*number+1]]
+			}
+			matches = append(matches, match{start: loc[0], key: key})
+		}
+	}
+	if len(ps) > 1 {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	}
+	keys := make([]string, 0, len(matches))
+	for _, m := range matches {
+		keys = append(keys, m.key)
+	}
+	return keys
+}
+
+// matchString reports whether any pattern matches text.
+func (ps issueKeyPatterns) matchString(text string) bool {
+	for _, re := range ps {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultIssuePattern matches keys such as PROJ-123 as whole words: a project key of up to
+// 10 characters (Jira's default limit) and an issue number of up to 7 digits. Fragments of
+// longer tokens, like hashes or identifiers in URLs, are not matched.
+const defaultIssuePattern = `\b[A-Z][A-Z0-9]{0,9}-\d{1,7}\b`
+
+// changeCategories names the change categories, in the order their commits are scanned.
+var changeCategories = []string{"features", "fixes", "breaking", "performance", "refactor", "docs", "other"}
+
+// categoryCommits returns the commits of the named change category.
+func categoryCommits(changes *plugin.CategorizedChanges, category string) []plugin.ConventionalCommit {
+	switch category {
+	case "features":
+		return changes.Features
+	case "fixes":
+		return changes.Fixes
+	case "breaking":
+		return changes.Breaking
+	case "performance":
+		return changes.Performance
+	case "refactor":
+		return changes.Refactor
+	case "docs":
+		return changes.Docs
+	case "other":
+		return changes.Other
+	}
+	return nil
+}
+
+// allCommits returns the commits of every change category in a stable order. A commit listed
+// in several categories (e.g. a breaking fix) is returned once, at its first occurrence.
+func allCommits(changes *plugin.CategorizedChanges) []plugin.ConventionalCommit {
+	return commitsIn(changes, changeCategories)
+}
+
+// issueCommits returns the commits scanned for issue keys: those of issue_categories, or of
+// every category when it is unset.
+func issueCommits(cfg *Config, changes *plugin.CategorizedChanges) []plugin.ConventionalCommit {
+	if len(cfg.IssueCategories) == 0 {
+		return allCommits(changes)
+	}
+	var categories []string
+	for _, category := range changeCategories {
+		if containsString(cfg.IssueCategories, category) {
+			categories = append(categories, category)
+		}
+	}
+	return commitsIn(changes, categories)
+}
+
+// commitsIn returns the commits of the given categories, deduplicated like allCommits.
+func commitsIn(changes *plugin.CategorizedChanges, categories []string) []plugin.ConventionalCommit {
+	if changes == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var commits []plugin.ConventionalCommit
+	for _, category := range categories {
+		for _, commit := range categoryCommits(changes, category) {
+			id := commitIdentity(commit)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// commitIdentity identifies a commit by its hash, or by its description when the hash is unknown.
+func commitIdentity(commit plugin.ConventionalCommit) string {
+	if commit.Hash != "" {
+		return "hash:" + commit.Hash
+	}
+	return "description:" + commit.Description
+}
+
+// commitsIssueKeys returns the issue keys of each commit (see commitIssueKeys). With
+// normalize_separators, keys written with a space or underscore (e.g. "PROJ 123") are added
+// as "PROJ-123" when their project is configured or referenced with a dash in any commit,
+// so phrases such as "HTTP 404" are not mistaken for keys.
+func commitsIssueKeys(cfg *Config, re issueKeyPatterns, commits []plugin.ConventionalCommit) [][]string {
+	keys := make([][]string, len(commits))
+	keywordRe := keywordPattern(cfg)
+	for i, commit := range commits {
+		keys[i] = commitIssueKeys(cfg, re, keywordRe, commit)
+	}
+	// Keyword references are lists of keys written with a dash
+	if !cfg.NormalizeSeparators || cfg.KeywordMode {
+		return keys
+	}
+
+	known := make(map[string]bool)
+	for _, projectKey := range cfg.projects() {
+		if projectKey != "" {
+			known[projectKey] = true
+		}
+	}
+	for prefix := range cfg.InstanceKeyMap {
+		known[strings.ToUpper(prefix)] = true
+	}
+	for _, commitKeys := range keys {
+		for _, key := range commitKeys {
+			known[issuePrefix(key)] = true
+		}
+	}
+	for i, commit := range commits {
+		for _, text := range []string{commit.Description, commit.Body} {
+			for _, match := range separatorKeyPattern.FindAllStringSubmatch(text, -1) {
+				if known[match[1]] {
+					keys[i] = append(keys[i], match[1]+"-"+match[2])
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// separatorKeyPattern matches issue keys written with a space or underscore instead of a
+// dash, such as "PROJ 123" or "PROJ_123".
+var separatorKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9]{0,9})[ _](\d{1,7})\b`)
+
+// commitIssueKeys returns the uppercased issue keys referenced by a commit in order of appearance.
+// Keys may repeat; callers deduplicate. keywordRe is the keyword_mode pattern (see keywordPattern).
+func commitIssueKeys(cfg *Config, re issueKeyPatterns, keywordRe *regexp.Regexp, commit plugin.ConventionalCommit) []string {
+	var keys []string
+
+	// With keyword_mode, only keys following a keyword such as "Closes" count
+	if cfg.KeywordMode {
+		for _, ref := range commitKeywordKeys(re, keywordRe, commit) {
+			keys = append(keys, ref.key)
+		}
+		return cfg.dropStandardIdentifiers(keys)
+	}
+
+	// Check description
+	for _, match := range re.findAll(commit.Description) {
+		keys = append(keys, strings.ToUpper(match))
+	}
+	// Also check body if present
+	if commit.Body != "" {
+		for _, match := range re.findAll(commit.Body) {
+			keys = append(keys, strings.ToUpper(match))
+		}
+	}
+	// Configured trailers may reference keys in any case (e.g. "Jira: proj-12")
+	for _, value := range trailerValues(commit.Body, cfg.TrailerKeys) {
+		keys = append(keys, re.findAll(strings.ToUpper(value))...)
+	}
+	// Also extract from referenced issues in the commit
+	for _, iss := range commit.Issues {
+		// URL references (e.g. .../browse/PROJ-1) contribute the keys found in their path
+		if path, ok := issueURLPath(iss); ok {
+			for _, match := range re.findAll(path) {
+				keys = append(keys, strings.ToUpper(match))
+			}
+			continue
+		}
+		upperMatch := strings.ToUpper(iss)
+		if re.matchString(upperMatch) {
+			keys = append(keys, upperMatch)
+		}
+	}
+
+	return cfg.dropStandardIdentifiers(keys)
+}
+
+// dropStandardIdentifiers removes standard identifiers such as UTF-8, which the default
+// pattern mistakes for keys.
+func (c *Config) dropStandardIdentifiers(keys []string) []string {
+	filtered := keys[:0]
+	for _, key := range keys {
+		if !c.standardIdentifier(key) {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
+
+// defaultKeywords are the keywords recognized by keyword_mode when keywords is unset.
+var defaultKeywords = []string{"closes", "fixes", "resolves"}
+
+// keywordPattern compiles the pattern matching the keywords recognized by keyword_mode, from
+// keywords or defaultKeywords. It returns nil without keyword_mode.
+func keywordPattern(cfg *Config) *regexp.Regexp {
+	if !cfg.KeywordMode {
+		return nil
+	}
+	keywords := cfg.Keywords
+	if len(keywords) == 0 {
+		keywords = defaultKeywords
+	}
+	alternatives := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		alternatives[i] = regexp.QuoteMeta(keyword)
+	}
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(alternatives, "|") + `)\b:?`)
+}
+
+// keywordReference is an issue key referenced after a keyword, as in "Fixes: PROJ-123".
+type keywordReference struct {
+	key     string
+	keyword string // lowercased
+}
+
+// commitKeywordKeys returns the keys a commit's description and body reference after a
+// keyword matched by keywordRe. A keyword, optionally followed by a colon, applies to the list of keys directly
+// after it on the same line: "Closes PROJ-1, PROJ-2 and PROJ-3".
+func commitKeywordKeys(re issueKeyPatterns, keywordRe *regexp.Regexp, commit plugin.ConventionalCommit) []keywordReference {
+	var refs []keywordReference
+	for _, text := range []string{commit.Description, commit.Body} {
+		for _, loc := range keywordRe.FindAllStringSubmatchIndex(text, -1) {
+			keyword := strings.ToLower(text[loc[2]:loc[3]])
+			rest, _, _ := strings.Cut(text[loc[1]:], "\n")
+			tokens := strings.FieldsFunc(rest, func(r rune) bool {
+				return unicode.IsSpace(r) || r == ',' || r == ';' || r == '&'
+			})
+			for _, token := range tokens {
+				if strings.EqualFold(token, "and") {
+					continue
+				}
+				matches := re.findAll(token)
+				if len(matches) == 0 {
+					break
+				}
+				for _, match := range matches {
+					refs = append(refs, keywordReference{key: strings.ToUpper(match), keyword: keyword})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// issueKeywords maps each of issueKeys to the first keyword it was referenced with under
+// keyword_mode.
+func issueKeywords(cfg *Config, changes *plugin.CategorizedChanges, issueKeys []string) map[string]string {
+	keywords := make(map[string]string, len(issueKeys))
+	re, err := issueKeyPattern(cfg)
+	if err != nil || !cfg.KeywordMode {
+		return keywords
+	}
+	keywordRe := keywordPattern(cfg)
+	for _, commit := range issueCommits(cfg, changes) {
+		for _, ref := range commitKeywordKeys(re, keywordRe, commit) {
+			if _, ok := keywords[ref.key]; !ok && containsString(issueKeys, ref.key) {
+				keywords[ref.key] = ref.keyword
+			}
+		}
+	}
+	return keywords
+}
+
+// standardPrefixes are prefixes of standard identifiers (UTF-8, SHA-256, ISO-8601, RFC-2119,
+// CVE-2024...) that match the default issue pattern.
+var standardPrefixes = map[string]bool{
+	"AES":  true,
+	"CVE":  true,
+	"CWE":  true,
+	"ECMA": true,
+	"IEC":  true,
+	"ISO":  true,
+	"MD":   true,
+	"PEP":  true,
+	"RFC":  true,
+	"SHA":  true,
+	"TLS":  true,
+	"UTF":  true,
+}
+
+// standardIdentifier reports whether key is a standard identifier rather than an issue key.
+// Only the default pattern is filtered; the configured project and mapped instance prefixes
+// are always treated as issue keys.
+func (c *Config) standardIdentifier(key string) bool {
+	if len(c.issuePatterns()) > 0 || c.DisableStandardDenylist {
+		return false
+	}
+	prefix, _, _ := strings.Cut(key, "-")
+	if _, ok := c.configuredProject(prefix); !standardPrefixes[prefix] || ok {
+		return false
+	}
+	_, mapped := c.InstanceKeyMap[prefix]
+	return !mapped
+}
+
+// trailerLinePattern matches a "Token: value" commit trailer line.
+var trailerLinePattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+// trailerValues returns the values of the named trailers in a commit body's trailer block: its
+// last paragraph, when every line is a trailer or an indented continuation. Names are matched
+// case-insensitively.
+func trailerValues(body string, names []string) []string {
+	if len(names) == 0 || body == "" {
+		return nil
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n")), "\n\n")
+	block := paragraphs[len(paragraphs)-1]
+
+	var values []string
+	matched := false
+	for _, line := range strings.Split(block, "\n") {
+		if line != "" && (line[0] == ' ' || line[0] == '\t') {
+			// Continuation of the previous trailer
+			if len(values) > 0 && matched {
+				values[len(values)-1] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		m := trailerLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		matched = false
+		for _, name := range names {
+			if strings.EqualFold(m[1], strings.TrimSpace(name)) {
+				values = append(values, m[2])
+				matched = true
+				break
+			}
+		}
+	}
+	return values
+}
+
+// commentsUse reports whether any of the per-issue comment templates contains text.
+func (c *Config) commentsUse(text string) bool {
+	for _, template := range []string{c.CommentTemplate, c.CreatedCommentTemplate, c.ReleasedCommentTemplate, c.BreakingCommentTemplate, c.RevertCommentTemplate, c.PrimaryCommentTemplate} {
+		if strings.Contains(template, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusCommentTemplate returns the comment template for whether the version was released
+// during this run, falling back to comment_template.
+func (c *Config) statusCommentTemplate(released bool) string {
+	if released && c.ReleasedCommentTemplate != "" {
+		return c.ReleasedCommentTemplate
+	}
+	if !released && c.CreatedCommentTemplate != "" {
+		return c.CreatedCommentTemplate
+	}
+	return c.CommentTemplate
+}
+
+// releasedLabel renders the {released} placeholder.
+func releasedLabel(released bool) string {
+	if released {
+		return "released"
+	}
+	return "not released"
+}
+
+// revertFooterPattern matches the footers marking a commit as a revert.
+var revertFooterPattern = regexp.MustCompile(`(?mi)^(Reverts\b|This reverts commit\b)`)
+
+// isRevertCommit reports whether a commit reverts an earlier change, either by its
+// conventional type, a git-generated "Revert" subject or a revert footer.
+func isRevertCommit(commit plugin.ConventionalCommit) bool {
+	return strings.EqualFold(commit.Type, "revert") ||
+		strings.HasPrefix(commit.Description, `Revert "`) ||
+		revertFooterPattern.MatchString(commit.Body)
+}
+
+// revertedIssues returns the issue keys referenced by revert commits.
+func revertedIssues(cfg *Config, changes *plugin.CategorizedChanges) map[string]bool {
+	reverted := make(map[string]bool)
+
+	re, err := issueKeyPattern(cfg)
+	if err != nil {
+		return reverted
+	}
+	keywordRe := keywordPattern(cfg)
+
+	for _, commit := range allCommits(changes) {
+		if !isRevertCommit(commit) {
+			continue
+		}
+		for _, key := range commitIssueKeys(cfg, re, keywordRe, commit) {
+			reverted[key] = true
+		}
+	}
+	return reverted
+}
+
+// breakingIndex records the migration notes of breaking commits per issue key, and which
+// issues are also referenced by non-breaking commits.
+type breakingIndex struct {
+	notes       map[string][]string
+	nonBreaking map[string]bool
+}
+
+// indexBreakingChanges builds the breaking-change index for the release's commits.
+func indexBreakingChanges(cfg *Config, changes *plugin.CategorizedChanges) breakingIndex {
+	idx := breakingIndex{notes: make(map[string][]string), nonBreaking: make(map[string]bool)}
+
+	re, err := issueKeyPattern(cfg)
+	if err != nil || changes == nil {
+		return idx
+	}
+	keywordRe := keywordPattern(cfg)
+
+	inBreaking := make(map[string]bool)
+	for _, commit := range changes.Breaking {
+		inBreaking[commit.Hash+"\x00"+commit.Description] = true
+	}
+
+	for _, commit := range allCommits(changes) {
+		breaking := commit.Breaking || inBreaking[commit.Hash+"\x00"+commit.Description]
+		note := commit.BreakingDescription
+		if note == "" {
+			note = commit.Body
+		}
+
+		seen := make(map[string]bool)
+		for _, key := range commitIssueKeys(cfg, re, keywordRe, commit) {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if !breaking {
+				idx.nonBreaking[key] = true
+				continue
+			}
+			notes := idx.notes[key]
+			if note != "" && !containsString(notes, note) {
+				notes = append(notes, note)
+			}
+			// The key is recorded even without notes so only() can see it
+			idx.notes[key] = notes
+		}
+	}
+
+	return idx
+}
+
+// only reports whether an issue is referenced exclusively by breaking changes.
+func (b breakingIndex) only(issueKey string) bool {
+	_, breaking := b.notes[issueKey]
+	return breaking && !b.nonBreaking[issueKey]
+}
+
+// notesFor returns the breaking-change notes for an issue, separated by blank lines.
+func (b breakingIndex) notesFor(issueKey string) string {
+	return strings.Join(b.notes[issueKey], "\n\n")
+}
+
+// siblingIssues maps each issue key to the other keys referenced by the same commits.
+func siblingIssues(cfg *Config, changes *plugin.CategorizedChanges) map[string][]string {
+	siblings := make(map[string][]string)
+
+	re, err := issueKeyPattern(cfg)
+	if err != nil {
+		return siblings
+	}
+	keywordRe := keywordPattern(cfg)
+
+	for _, commit := range allCommits(changes) {
+		var keys []string
+		for _, key := range commitIssueKeys(cfg, re, keywordRe, commit) {
+			if !containsString(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys {
+			for _, other := range keys {
+				if other != key && !containsString(siblings[key], other) {
+					siblings[key] = append(siblings[key], other)
+				}
+			}
+		}
+	}
+
+	return siblings
+}
+
+// pullRequestPattern matches the "(#123)" suffix GitHub adds to squash-merged commit subjects.
+var pullRequestPattern = regexp.MustCompile(`\(#(\d+)\)`)
+
+// commitPullRequests returns the pull requests a commit came from, as URLs when the
+// repository URL is known and as "#123" otherwise.
+func commitPullRequests(commit plugin.ConventionalCommit, repositoryURL string) []string {
+	var pulls []string
+	for _, ref := range commit.Issues {
+		if path, ok := issueURLPath(ref); ok && (strings.Contains(path, "/pull/") || strings.Contains(path, "/merge_requests/")) {
+			pulls = append(pulls, ref)
+		}
+	}
+	for _, match := range pullRequestPattern.FindAllStringSubmatch(commit.Description, -1) {
+		if repositoryURL != "" {
+			pulls = append(pulls, strings.TrimSuffix(repositoryURL, "/")+"/pull/"+match[1])
+		} else {
+			pulls = append(pulls, "#"+match[1])
+		}
+	}
+	return pulls
+}
+
+// issuePullRequests maps each issue key to the pull requests of the commits referencing it.
+// Commits without pull request data contribute nothing.
+func issuePullRequests(cfg *Config, releaseCtx plugin.ReleaseContext) map[string][]string {
+	pulls := make(map[string][]string)
+
+	re, err := issueKeyPattern(cfg)
+	if err != nil {
+		return pulls
+	}
+	keywordRe := keywordPattern(cfg)
+
+	for _, commit := range allCommits(releaseCtx.Changes) {
+		commitPulls := commitPullRequests(commit, releaseCtx.RepositoryURL)
+		if len(commitPulls) == 0 {
+			continue
+		}
+		for _, key := range commitIssueKeys(cfg, re, keywordRe, commit) {
+			for _, pull := range commitPulls {
+				if !containsString(pulls[key], pull) {
+					pulls[key] = append(pulls[key], pull)
+				}
+			}
+		}
+	}
+
+	return pulls
+}
+
+// normalizeUnicode replaces code points outside the Basic Multilingual Plane (4-byte UTF-8,
+// e.g. emoji), which some older Jira Server databases reject, with U+FFFD.
+func normalizeUnicode(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF {
+			return utf8.RuneError
+		}
+		return r
+	}, text)
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// issueURLPath returns the path of an issue reference given as an http(s) URL.
+func issueURLPath(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "http://") && !strings.HasPrefix(ref, "https://") {
+		return "", false
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return "", false
+	}
+	return parsed.Path, true
+}
+
+// permissionRequirement is a Jira project permission needed by an enabled action.
+type permissionRequirement struct {
+	Key    string
+	Reason string
+}
+
+// requiredPermissions lists the project permissions needed by the enabled, non-dry-run actions.
+func requiredPermissions(cfg *Config, modes actionDryRun, hasIssues bool) []permissionRequirement {
+	var reqs []permissionRequirement
+	if (cfg.CreateVersion || cfg.ReleaseVersion) && !modes.Versions {
+		// Jira gates version management behind project administration
+		reqs = append(reqs, permissionRequirement{Key: "ADMINISTER_PROJECTS", Reason: "create/release versions"})
+	}
+	if !hasIssues {
+		return reqs
+	}
+	if cfg.AssociateIssues && !modes.Associations {
+		reqs = append(reqs, permissionRequirement{Key: "EDIT_ISSUES", Reason: "associate issues with the version"})
+	}
+	if cfg.AddLabels && !modes.Associations {
+		reqs = append(reqs, permissionRequirement{Key: "EDIT_ISSUES", Reason: "add labels"})
+	}
+	if cfg.SetComponent && !modes.Associations {
+		reqs = append(reqs, permissionRequirement{Key: "EDIT_ISSUES", Reason: "set components"})
+	}
+	if cfg.TransitionIssues && cfg.transitionConfigured() && !modes.Transitions {
+		reqs = append(reqs, permissionRequirement{Key: "TRANSITION_ISSUES", Reason: "transition issues"})
+	}
+	if (cfg.perIssueComments() || cfg.summaryComments()) && !modes.Comments {
+		reqs = append(reqs, permissionRequirement{Key: "ADD_COMMENTS", Reason: "add comments"})
+	}
+	return reqs
+}
+
+// verifyPermissions confirms the configured user holds every required project permission.
+func (p *JiraPlugin) verifyPermissions(ctx context.Context, client *jira.Client, projectKey string, reqs []permissionRequirement) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(reqs))
+	for i, r := range reqs {
+		keys[i] = r.Key
+	}
+
+	perms, err := client.Permission.GetMyPermissions(ctx, &permission.MyPermissionsOptions{
+		ProjectKey:  projectKey,
+		Permissions: strings.Join(keys, ","),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify permissions: %w", err)
+	}
+
+	var missing []string
+	for _, r := range reqs {
+		status, ok := perms.Permissions[r.Key]
+		if !ok || !status.HavePermission {
+			missing = append(missing, fmt.Sprintf("%s (needed to %s)", r.Key, r.Reason))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing Jira permissions in project %s: %s", projectKey, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// agileIssue is the subset of the Jira Software issue representation describing sprint membership.
+type agileIssue struct {
+	Fields struct {
+		Sprint *struct {
+			State string `json:"state"`
+		} `json:"sprint"`
+		ClosedSprints []struct {
+			State string `json:"state"`
+		} `json:"closedSprints"`
+	} `json:"fields"`
+}
+
+// inClosedSprint reports whether an issue only belongs to closed sprints.
+// Lookup failures (e.g. Jira Software not installed) keep the issue.
+func (p *JiraPlugin) inClosedSprint(ctx context.Context, client *jira.Client, issueKey string) bool {
+	path := fmt.Sprintf("/rest/agile/1.0/issue/%s?fields=sprint,closedSprints", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return false
+	}
+
+	var result agileIssue
+	if err := client.Transport.DecodeResponse(resp, &result); err != nil {
+		return false
+	}
+
+	if result.Fields.Sprint != nil && !strings.EqualFold(result.Fields.Sprint.State, "closed") {
+		return false
+	}
+	return result.Fields.Sprint != nil || len(result.Fields.ClosedSprints) > 0
+}
+
+// doneIssues returns the issues whose status is in Jira's done category. Issues whose status
+// cannot be read are treated as open so they still get commented.
+func (p *JiraPlugin) doneIssues(ctx context.Context, router issueRouter, issueKeys []string) []string {
+	done := []string{}
+	for _, issueKey := range issueKeys {
+		iss, err := router.client(issueKey).Issue.Get(ctx, issueKey, &issue.GetOptions{Fields: []string{"status"}})
+		if err != nil {
+			continue
+		}
+		if status := iss.GetStatus(); status != nil && status.Category != nil && strings.EqualFold(status.Category.Key, "done") {
+			done = append(done, issueKey)
+		}
+	}
+	return done
+}
+
+// issueComponents returns the issue's component names, comma-separated. Issues that cannot be
+// read render as having no components.
+func (p *JiraPlugin) issueComponents(ctx context.Context, client *jira.Client, issueKey string) string {
+	iss, err := client.Issue.Get(ctx, issueKey, &issue.GetOptions{Fields: []string{"components"}})
+	if err != nil {
+		return ""
+	}
+	var names []string
+	for _, component := range iss.GetComponents() {
+		if component != nil && component.Name != "" {
+			names = append(names, component.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// issueSkips records why issues were skipped, in the order they were first skipped.
+type issueSkips struct {
+	keys    []string
+	reasons map[string]string
+}
+
+// newIssueSkips returns an empty skip record.
+func newIssueSkips() *issueSkips {
+	return &issueSkips{reasons: make(map[string]string)}
+}
+
+// add records that an issue was skipped. The first reason recorded for an issue wins.
+func (s *issueSkips) add(issueKey, reason string) {
+	if _, ok := s.reasons[issueKey]; ok {
+		return
+	}
+	s.keys = append(s.keys, issueKey)
+	s.reasons[issueKey] = reason
+}
+
+// count returns the number of skipped issues.
+func (s *issueSkips) count() int {
+	return len(s.keys)
+}
+
+// summary returns a line such as "12 processed, 3 skipped (2 missing, 1 closed sprint)".
+// Reasons are ordered by count, then name.
+func (s *issueSkips) summary(total int) string {
+	counts := make(map[string]int)
+	var reasons []string
+	for _, issueKey := range s.keys {
+		reason := s.reasons[issueKey]
+		if counts[reason] == 0 {
+			reasons = append(reasons, reason)
+		}
+		counts[reason]++
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%d %s", counts[reason], reason)
+	}
+	return fmt.Sprintf("%d processed, %d skipped (%s)", total-s.count(), s.count(), strings.Join(parts, ", "))
+}
+
+// details lists each skipped issue with its reason, e.g. "PROJ-1 (missing)".
+func (s *issueSkips) details() []string {
+	details := make([]string, len(s.keys))
+	for i, issueKey := range s.keys {
+		details[i] = fmt.Sprintf("%s (%s)", issueKey, s.reasons[issueKey])
+	}
+	return details
+}
+
+// issueOutcomes records which issues had every operation succeed and which had one fail.
+type issueOutcomes struct {
+	keys   []string          // issues with a recorded outcome, in first-seen order
+	errors map[string]string // first failure per issue, as "step: error"
+}
+
+// newIssueOutcomes returns an empty outcome record.
+func newIssueOutcomes() *issueOutcomes {
+	return &issueOutcomes{errors: make(map[string]string)}
+}
+
+// succeed records a successful operation on an issue.
+func (o *issueOutcomes) succeed(issueKey string) {
+	if !containsString(o.keys, issueKey) {
+		o.keys = append(o.keys, issueKey)
+	}
+}
+
+// fail records a failed operation on an issue. The first failure recorded for an issue wins.
+func (o *issueOutcomes) fail(issueKey, step string, err error) {
+	o.succeed(issueKey)
+	if _, ok := o.errors[issueKey]; !ok {
+		o.errors[issueKey] = fmt.Sprintf("%s: %v", step, err)
+	}
+}
+
+// succeeded returns the issues whose operations all succeeded.
+func (o *issueOutcomes) succeeded() []string {
+	succeeded := []string{}
+	for _, issueKey := range o.keys {
+		if _, ok := o.errors[issueKey]; !ok {
+			succeeded = append(succeeded, issueKey)
+		}
+	}
+	return succeeded
+}
+
+// failed returns the issues with at least one failed operation.
+func (o *issueOutcomes) failed() []string {
+	failed := []string{}
+	for _, issueKey := range o.keys {
+		if _, ok := o.errors[issueKey]; ok {
+			failed = append(failed, issueKey)
+		}
+	}
+	return failed
+}
+
+// outputs returns the succeeded_issues, failed_issues and issue_errors outputs.
+func (o *issueOutcomes) outputs() map[string]any {
+	return map[string]any{
+		"succeeded_issues": o.succeeded(),
+		"failed_issues":    o.failed(),
+		"issue_errors":     o.errors,
+	}
+}
+
+// jiraInstance is a Jira site hosting some of the referenced projects.
+type jiraInstance struct {
+	client  *jira.Client
+	baseURL string
+}
+
+// issueRouter sends each issue's operations to the Jira instance hosting its project.
+type issueRouter struct {
+	primary   jiraInstance
+	instances map[string]jiraInstance // by project prefix
+}
+
+// newIssueRouter creates clients for the instances in instance_key_map, reusing the
+// primary instance's credentials, limiter and the given middlewares.
+func (p *JiraPlugin) newIssueRouter(cfg *Config, primary *jira.Client, limiter *requestLimiter, middlewares ...transport.Middleware) (issueRouter, error) {
+	router := issueRouter{
+		primary:   jiraInstance{client: primary, baseURL: cfg.BaseURL},
+		instances: make(map[string]jiraInstance, len(cfg.InstanceKeyMap)),
+	}
+	for prefix, baseURL := range cfg.InstanceKeyMap {
+		instanceCfg := *cfg
+		instanceCfg.BaseURL = baseURL
+		client, err := p.getClient(&instanceCfg, limiter, middlewares...)
+		if err != nil {
+			return issueRouter{}, fmt.Errorf("failed to create Jira client for %s issues: %w", prefix, err)
+		}
+		router.instances[strings.ToUpper(prefix)] = jiraInstance{client: client, baseURL: baseURL}
+	}
+	return router, nil
+}
+
+// instance returns the instance hosting an issue, falling back to the primary one.
+func (r issueRouter) instance(issueKey string) jiraInstance {
+	if instance, ok := r.instances[issuePrefix(issueKey)]; ok {
+		return instance
+	}
+	return r.primary
+}
+
+// client returns the client for the instance hosting an issue.
+func (r issueRouter) client(issueKey string) *jira.Client {
+	return r.instance(issueKey).client
+}
+
+// baseURL returns the base URL of the instance hosting an issue.
+func (r issueRouter) baseURL(issueKey string) string {
+	return r.instance(issueKey).baseURL
+}
+
+// federated reports whether any issues may be routed to another instance.
+func (r issueRouter) federated() bool {
+	return len(r.instances) > 0
+}
+
+// issuePrefix returns the project part of an issue key.
+func issuePrefix(issueKey string) string {
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		return issueKey[:i]
+	}
+	return issueKey
+}
+
+// routeIssues splits issue keys into those hosted on a known instance and those whose
+// prefix has no mapped instance. Without instance_key_map every key is kept.
+func routeIssues(cfg *Config, issueKeys []string) (routed, unmapped []string) {
+	if len(cfg.InstanceKeyMap) == 0 {
+		return issueKeys, nil
+	}
+
+	unmapped = []string{}
+	for _, issueKey := range issueKeys {
+		prefix := issuePrefix(issueKey)
+		_, mapped := cfg.InstanceKeyMap[prefix]
+		if _, ok := cfg.configuredProject(prefix); mapped || ok {
+			routed = append(routed, issueKey)
+		} else {
+			unmapped = append(unmapped, issueKey)
+		}
+	}
+	return routed, unmapped
+}
+
+// issueSnapshot is an issue's state before the release changed it.
+type issueSnapshot struct {
+	Status      string   `json:"status"`
+	FixVersions []string `json:"fix_versions"`
+}
+
+// captureSnapshot records the status and fix versions of each issue. Issues that cannot be
+// read are left out of the snapshot.
+func (p *JiraPlugin) captureSnapshot(ctx context.Context, router issueRouter, issueKeys []string) map[string]issueSnapshot {
+	snapshot := make(map[string]issueSnapshot, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		iss, err := router.client(issueKey).Issue.Get(ctx, issueKey, &issue.GetOptions{Fields: []string{"status", "fixVersions"}})
+		if err != nil {
+			continue
+		}
+
+		fixVersions := []string{}
+		for _, v := range iss.GetFixVersions() {
+			fixVersions = append(fixVersions, v.Name)
+		}
+		snapshot[issueKey] = issueSnapshot{
+			Status:      iss.GetStatusName(),
+			FixVersions: fixVersions,
+		}
+	}
+	return snapshot
+}
+
+// movedIssues caches the current keys of moved issues, keyed by the key found in commits.
+// It is shared by the issues processed concurrently.
+type movedIssues struct {
+	enabled bool
+	mu      sync.Mutex
+	keys    map[string]string
+}
+
+// current returns the cached current key of a moved issue.
+func (m *movedIssues) current(issueKey string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.keys[issueKey]
+	return current, ok
+}
+
+// record caches the current key of a moved issue.
+func (m *movedIssues) record(issueKey, current string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[issueKey] = current
+}
+
+// withMovedIssue runs op against an issue. When following moved issues is enabled and Jira
+// reports the issue as missing, op is retried against the issue's current key.
+func (p *JiraPlugin) withMovedIssue(ctx context.Context, client *jira.Client, moved *movedIssues, issueKey string, op func(key string) error) error {
+	if current, ok := moved.current(issueKey); ok {
+		return op(current)
+	}
+
+	err := op(issueKey)
+	if err == nil || !moved.enabled || !isNotFound(err) {
+		return err
+	}
+
+	current, resolveErr := p.resolveMovedIssue(ctx, client, issueKey)
+	if resolveErr != nil || current == issueKey {
+		return err
+	}
+	moved.record(issueKey, current)
+	return op(current)
+}
+
+// resolveMovedIssue returns the current key of an issue, searching by a possibly old key.
+func (p *JiraPlugin) resolveMovedIssue(ctx context.Context, client *jira.Client, issueKey string) (string, error) {
+	result, err := client.Search.SearchJQL(ctx, &search.SearchJQLOptions{
+		JQL:        fmt.Sprintf("key = %q", issueKey),
+		Fields:     []string{"key"},
+		MaxResults: 1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search for issue %s: %w", issueKey, err)
+	}
+	if len(result.Issues) == 0 || result.Issues[0].Key == "" {
+		return "", fmt.Errorf("issue %s not found", issueKey)
+	}
+	return result.Issues[0].Key, nil
+}
+
+// isNotFound reports whether err is a Jira 404.
+func isNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// hasStatus reports whether err is a Jira error response with the given HTTP status.
+func hasStatus(err error, status int) bool {
+	var errResp *transport.ErrorResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == status
+}
+
+// Policies for choosing between several versions that share a name.
+const (
+	ambiguousVersionFail             = "fail"
+	ambiguousVersionPreferUnreleased = "prefer_unreleased"
+	ambiguousVersionPreferNewest     = "prefer_newest"
+)
+
+// Strategies for announcing a release on its issues.
+const (
+	commentStrategyPerIssue    = "per_issue"
+	commentStrategySummaryOnly = "summary_only"
+	commentStrategyBoth        = "both"
+)
+
+// perIssueComments reports whether each issue receives its own release comment.
+func (c *Config) perIssueComments() bool {
+	return c.AddComment && c.CommentTemplate != "" && c.CommentStrategy != commentStrategySummaryOnly
+}
+
+// summaryComments reports whether a summary comment is posted to SummaryCommentIssue.
+func (c *Config) summaryComments() bool {
+	return c.AddComment && c.CommentStrategy != commentStrategyPerIssue && c.SummaryCommentIssue != ""
+}
+
+// Selectors for the primary issue receiving primary_comment_template.
+const (
+	primaryIssueFirstSeen  = "first_seen"
+	primaryIssueLowestKey  = "lowest_key"
+	primaryIssueMostRecent = "most_recent"
+)
+
+// primaryIssue returns the issue receiving PrimaryCommentTemplate, or "" when it is unset.
+// Issues whose update time cannot be read are never the most recent.
+func (p *JiraPlugin) primaryIssue(ctx context.Context, cfg *Config, router issueRouter, issueKeys []string) string {
+	if cfg.PrimaryCommentTemplate == "" || len(issueKeys) == 0 {
+		return ""
+	}
+
+	primary := issueKeys[0]
+	switch cfg.PrimaryIssueSelector {
+	case primaryIssueLowestKey:
+		for _, issueKey := range issueKeys[1:] {
+			if issueKeyLess(issueKey, primary) {
+				primary = issueKey
+			}
+		}
+	case primaryIssueMostRecent:
+		var latest time.Time
+		for _, issueKey := range issueKeys {
+			iss, err := router.client(issueKey).Issue.Get(ctx, issueKey, &issue.GetOptions{Fields: []string{"updated"}})
+			if err != nil {
+				continue
+			}
+			if updated := iss.GetUpdated(); updated != nil && updated.After(latest) {
+				primary, latest = issueKey, *updated
+			}
+		}
+	}
+	return primary
+}
+
+// issueKeyLess orders issue keys by project, then numerically by issue number, so PROJ-9
+// comes before PROJ-10.
+func issueKeyLess(a, b string) bool {
+	if prefixA, prefixB := issuePrefix(a), issuePrefix(b); prefixA != prefixB {
+		return prefixA < prefixB
+	}
+	numA, errA := strconv.Atoi(strings.TrimPrefix(a, issuePrefix(a)+"-"))
+	numB, errB := strconv.Atoi(strings.TrimPrefix(b, issuePrefix(b)+"-"))
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return numA < numB
+}
+
+// Formats comment bodies are posted in.
+const (
+	commentFormatText = "text"
+	commentFormatADF  = "adf"
+)
+
+// Issue fields a release version can be set on.
+const (
+	versionFieldFix     = "fix"
+	versionFieldAffects = "affects"
+)
+
+// versionFieldID returns the Jira field ID for VersionField.
+func (c *Config) versionFieldID() string {
+	if c.VersionField == versionFieldAffects {
+		return "versions"
+	}
+	return "fixVersions"
+}
+
+// Policies for a version name that already exists in the project.
+const (
+	existingVersionReuse  = "reuse"
+	existingVersionSuffix = "suffix"
+)
+
+// maxVersionNameSuffix caps the suffixes tried when looking for an unused version name.
+const maxVersionNameSuffix = 100
+
+// freeVersionName returns versionName if no project version uses it, otherwise the first
+// unused name of the form "1.2.3 (2)", "1.2.3 (3)", and so on.
+func (p *JiraPlugin) freeVersionName(ctx context.Context, client *jira.Client, projectKey, versionName string) (string, error) {
+	versions, err := p.projectVersions(ctx, client, projectKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to list project versions: %w", err)
+	}
+
+	taken := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		taken[v.Name] = true
+	}
+	if !taken[versionName] {
+		return versionName, nil
+	}
+	for n := 2; n <= maxVersionNameSuffix; n++ {
+		candidate := fmt.Sprintf("%s (%d)", versionName, n)
+		if !taken[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no unused name for version '%s' up to suffix (%d)", versionName, maxVersionNameSuffix)
+}
+
+// Modes for matching existing version names against the release version.
+const (
+	versionMatchExact    = "exact"
+	versionMatchContains = "contains"
+	versionMatchPrefix   = "prefix"
+)
+
+// versionLookup controls how an existing version is resolved by name.
+type versionLookup struct {
+	// MatchMode is how names are compared: exact, contains or prefix.
+	MatchMode string
+	// OnAmbiguous decides which version is used when several match.
+	OnAmbiguous string
+}
+
+// versionLookup returns the version lookup settings for the configuration.
+func (c *Config) versionLookup() versionLookup {
+	return versionLookup{
+		MatchMode:   c.VersionMatchMode,
+		OnAmbiguous: c.OnAmbiguousVersion,
+	}
+}
+
+// versionNameMatches reports whether an existing version name matches the release version.
+// Partial matches must not run into neighboring version characters, so "1.2.3" does not
+// match "1.2.30" or "11.2.3".
+func versionNameMatches(name, versionName, mode string) bool {
+	if name == versionName {
+		return true
+	}
+	if versionName == "" {
+		return false
+	}
+	switch mode {
+	case versionMatchPrefix:
+		return strings.HasPrefix(name, versionName) && versionBoundary(name[len(versionName):], false)
+	case versionMatchContains:
+		for offset := 0; offset+len(versionName) <= len(name); offset++ {
+			if strings.HasPrefix(name[offset:], versionName) &&
+				versionBoundary(name[:offset], true) && versionBoundary(name[offset+len(versionName):], false) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// versionBoundary reports whether the text next to a partial version match ends the version:
+// it is empty, or the adjacent rune is not a letter, digit or dot. before selects the text
+// preceding the match (its last rune) rather than the text following it (its first rune).
+func versionBoundary(text string, before bool) bool {
+	if text == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(text)
+	if before {
+		r, _ = utf8.DecodeLastRuneInString(text)
+	}
+	return r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// versionsPageSize is the number of versions requested per page.
+const versionsPageSize = 50
+
+// projectVersions lists every version of a project. The paginated version endpoint is read
+// page by page, so versions beyond the first page are found on projects with many releases.
+func (p *JiraPlugin) projectVersions(ctx context.Context, client *jira.Client, projectKey string) ([]*project.Version, error) {
+	versions := []*project.Version{}
+	for startAt := 0; ; {
+		path := fmt.Sprintf("/rest/api/3/project/%s/version?startAt=%d&maxResults=%d", url.PathEscape(projectKey), startAt, versionsPageSize)
+		req, err := client.Transport.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Transport.Do(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		var page struct {
+			Values []*project.Version `json:"values"`
+			IsLast bool               `json:"isLast"`
+		}
+		if err := client.Transport.DecodeResponse(resp, &page); err != nil {
+			return nil, err
+		}
+		versions = append(versions, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return versions, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// findVersion returns the project version matching the given name, or nil if none exists.
+// An exact match wins over partial ones; otherwise lookup.OnAmbiguous decides between
+// several matches.
+func (p *JiraPlugin) findVersion(ctx context.Context, client *jira.Client, projectKey, versionName string, lookup versionLookup) (*project.Version, error) {
+	versions, err := p.projectVersions(ctx, client, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project versions: %w", err)
+	}
+
+	var exact, partial []*project.Version
+	for _, v := range versions {
+		switch {
+		case v.Name == versionName:
+			exact = append(exact, v)
+		case versionNameMatches(v.Name, versionName, lookup.MatchMode):
+			partial = append(partial, v)
+		}
+	}
+	if len(exact) > 0 {
+		return selectVersion(exact, versionName, lookup.OnAmbiguous)
+	}
+	return selectVersion(partial, versionName, lookup.OnAmbiguous)
+}
+
+// selectVersion picks one of the versions matching a name according to the ambiguity policy.
+// Among equally preferred versions, the newest (highest ID) wins.
+func selectVersion(matches []*project.Version, versionName, onAmbiguous string) (*project.Version, error) {
+	switch {
+	case len(matches) == 0:
+		return nil, nil
+	case len(matches) == 1:
+		return matches[0], nil
+	}
+
+	candidates := matches
+	switch onAmbiguous {
+	case ambiguousVersionPreferNewest:
+	case ambiguousVersionPreferUnreleased:
+		var unreleased []*project.Version
+		for _, v := range matches {
+			if !v.Released {
+				unreleased = append(unreleased, v)
+			}
+		}
+		if len(unreleased) > 0 {
+			candidates = unreleased
+		}
+	default:
+		ids := make([]string, len(matches))
+		for i, v := range matches {
+			ids[i] = v.ID
+		}
+		return nil, fmt.Errorf("%d versions named '%s' found (IDs %s); set on_ambiguous_version to choose one", len(matches), versionName, strings.Join(ids, ", "))
+	}
+
+	newest := candidates[0]
+	for _, v := range candidates[1:] {
+		if versionIDNewer(v.ID, newest.ID) {
+			newest = v
+		}
+	}
+	return newest, nil
+}
+
+// versionIDNewer reports whether version ID a was created after b. Jira IDs are increasing
+// integers; non-numeric IDs fall back to string comparison.
+func versionIDNewer(a, b string) bool {
+	ai, errA := strconv.ParseInt(a, 10, 64)
+	bi, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+	return ai > bi
+}
+
+// createOrGetVersion creates a new version or returns existing one. A created version is
+// awaited for up to visibilityTimeout, if set, so it can be resolved before issues reference it.
+func (p *JiraPlugin) createOrGetVersion(ctx context.Context, client *jira.Client, projectKey, versionName, description, startDate string, lookup versionLookup, visibilityTimeout time.Duration) (*project.Version, error) {
+	// Try to find existing version first by listing project versions
+	existing, err := p.findVersion(ctx, client, projectKey, versionName, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	// Create new version using jirasdk
+	createdVersion, err := client.Project.CreateVersion(ctx, &project.CreateVersionInput{
+		Name:        versionName,
+		Description: description,
+		Project:     projectKey,
+		StartDate:   startDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create version: %w", err)
+	}
+	if visibilityTimeout > 0 {
+		if err := p.waitForVersion(ctx, client, createdVersion.ID, visibilityTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	return createdVersion, nil
+}
+
+// maxVersionVisibilityTimeout is the longest accepted version_visibility_timeout_seconds.
+const maxVersionVisibilityTimeout = 60
+
+// versionPollInterval is the delay between checks for a newly created version.
+var versionPollInterval = 500 * time.Millisecond
+
+// versionVisibilityTimeout returns how long to wait for a created version to become readable.
+func (c *Config) versionVisibilityTimeout() time.Duration {
+	return time.Duration(c.VersionVisibilityTimeout) * time.Second
+}
+
+// waitForVersion polls a newly created version until Jira resolves it. Jira Cloud may answer
+// 404 for a version shortly after creating it, which would make associating issues fail.
+func (p *JiraPlugin) waitForVersion(ctx context.Context, client *jira.Client, versionID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := client.Project.GetVersion(ctx, versionID)
+		if err == nil {
+			return nil
+		}
+		if !hasStatus(err, http.StatusNotFound) {
+			return fmt.Errorf("failed to check created version %s: %w", versionID, err)
+		}
+		if time.Now().Add(versionPollInterval).After(deadline) {
+			return fmt.Errorf("created version %s is still not visible after %s", versionID, timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(versionPollInterval):
+		}
+	}
+}
+
+// previousVersion returns the version to archive after releasing versionName: the most
+// recently released version created before it that is not archived yet, or nil if none exists.
+func (p *JiraPlugin) previousVersion(ctx context.Context, client *jira.Client, projectKey, versionName string) (*project.Version, error) {
+	versions, err := p.projectVersions(ctx, client, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project versions: %w", err)
+	}
+	return selectPreviousVersion(versions, versionName), nil
+}
+
+// selectPreviousVersion picks the previous version among a project's versions. A version that
+// does not exist yet is newer than every other. Release dates decide between candidates, then
+// creation order.
+func selectPreviousVersion(versions []*project.Version, versionName string) *project.Version {
+	currentID := ""
+	for _, v := range versions {
+		if v.Name == versionName && (currentID == "" || versionIDNewer(v.ID, currentID)) {
+			currentID = v.ID
+		}
+	}
+
+	var previous *project.Version
+	for _, v := range versions {
+		if !v.Released || v.Archived || v.Name == versionName {
+			continue
+		}
+		if currentID != "" && !versionIDNewer(currentID, v.ID) {
+			continue
+		}
+		if previous == nil || v.ReleaseDate > previous.ReleaseDate ||
+			(v.ReleaseDate == previous.ReleaseDate && versionIDNewer(v.ID, previous.ID)) {
+			previous = v
+		}
+	}
+	return previous
+}
+
+// archiveVersion archives a version, hiding it from version pickers.
+func (p *JiraPlugin) archiveVersion(ctx context.Context, client *jira.Client, versionID string) error {
+	archived := true
+
+	_, err := client.Project.UpdateVersion(ctx, versionID, &project.UpdateVersionInput{
+		Archived: &archived,
+	})
+	return err
+}
+
+// releaseVersion marks a version as released on the given date (YYYY-MM-DD).
+func (p *JiraPlugin) releaseVersion(ctx context.Context, client *jira.Client, versionID, date string) error {
+	released := true
+
+	_, err := client.Project.UpdateVersion(ctx, versionID, &project.UpdateVersionInput{
+		Released:    &released,
+		ReleaseDate: date,
+	})
+	return err
+}
+
+// location returns the configured time zone, falling back to UTC when it is unset or unknown.
+func (c *Config) location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// versionReleaseDate returns the release date for the version: the configured release_date,
+// or today's date in the configured time zone (see releaseDate).
+func (c *Config) versionReleaseDate(clock *serverClock) string {
+	if c.ReleaseDate != "" && !strings.EqualFold(c.ReleaseDate, releaseDateToday) {
+		if date, err := parseVersionDate("release_date", c.ReleaseDate, c.location()); err == nil {
+			return date
+		}
+	}
+	return releaseDate(timeNow().In(c.location()), clock, time.Duration(c.ClockSkewTolerance)*time.Second)
+}
+
+// versionStartDate returns the start date for created versions: the configured
+// version_start_date, today's date in the configured time zone for "today", or "" if unset.
+func (c *Config) versionStartDate() string {
+	switch {
+	case c.VersionStartDate == "":
+		return ""
+	case strings.EqualFold(c.VersionStartDate, releaseDateToday):
+		return timeNow().In(c.location()).Format("2006-01-02")
+	}
+	date, err := parseVersionDate("version_start_date", c.VersionStartDate, c.location())
+	if err != nil {
+		return ""
+	}
+	return date
+}
+
+// startDateNote describes the start date of created versions for dry-run messages, e.g.
+// " starting on 2024-03-01", or returns "" if none is set.
+func (c *Config) startDateNote() string {
+	if date := c.versionStartDate(); date != "" {
+		return " starting on " + date
+	}
+	return ""
+}
+
+// releaseDateToday is the release_date and version_start_date value for the current date.
+const releaseDateToday = "today"
+
+// parseVersionDate parses the value of a version date field, of the form YYYY-MM-DD or an
+// RFC 3339 timestamp taken as a date in loc, and returns it as YYYY-MM-DD.
+func parseVersionDate(field, value string, loc *time.Location) (string, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date.Format("2006-01-02"), nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", fmt.Errorf("%s must be 'today', a date (YYYY-MM-DD) or an RFC 3339 timestamp, got %q", field, value)
+	}
+	return timestamp.In(loc).Format("2006-01-02"), nil
+}
+
+// releaseDate computes today's date for a release. When the local clock runs ahead of the
+// Jira server by more than tolerance, the server's clock is used so the date is never in the future.
+func releaseDate(now time.Time, clock *serverClock, tolerance time.Duration) string {
+	if offset, ok := clock.offset(); ok && offset < -tolerance {
+		now = now.Add(offset)
+	}
+	return now.Format("2006-01-02")
+}
+
+// associateIssueWithVersion adds a fix version to an issue.
+func (p *JiraPlugin) associateIssueWithVersion(ctx context.Context, client *jira.Client, issueKey, field, versionName string) error {
+	// Send the update directly: Issue.Update reports failures as a bare status code, which
+	// loses the error response that isNotFound and the permission checks inspect
+	body := &issue.UpdateInput{
+		Fields: map[string]interface{}{
+			field: []map[string]string{
+				{"name": versionName},
+			},
+		},
+	}
+	path := fmt.Sprintf("/rest/api/3/issue/%s", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to update issue %s: %w", issueKey, err)
+	}
+	if resp.StatusCode < 300 {
+		_ = resp.Body.Close()
+		return nil
+	}
+	return fmt.Errorf("failed to update issue %s: %w", issueKey, client.Transport.DecodeResponse(resp, &struct{}{}))
+}
+
+// bulkEditPath is Jira Cloud's bulk edit endpoint; deployments without it fall back to per-issue updates.
+const bulkEditPath = "/rest/api/3/bulk/issues/fields"
+
+// bulkEditMaxIssues is the most issues Jira accepts in a single bulk edit.
+const bulkEditMaxIssues = 1000
+
+// bulkPollAttempts bounds how often a bulk edit task is polled before giving up.
+const bulkPollAttempts = 60
+
+// bulkPollInterval is the delay between bulk edit task polls.
+var bulkPollInterval = time.Second
+
+// bulkEditProgress is the status of a bulk edit task.
+type bulkEditProgress struct {
+	Status                          string              `json:"status"`
+	ProcessedAccessibleIssues       []int64             `json:"processedAccessibleIssues"`
+	FailedAccessibleIssues          map[string][]string `json:"failedAccessibleIssues"`
+	InvalidOrInaccessibleIssueCount int                 `json:"invalidOrInaccessibleIssueCount"`
+}
+
+// bulkAssociateIssues sets the fix or affects version (field) of many issues using bulk edit
+// tasks and waits for them to finish. It returns an error unless every issue was updated.
+func (p *JiraPlugin) bulkAssociateIssues(ctx context.Context, client *jira.Client, issueKeys []string, field, versionID string) error {
+	for start := 0; start < len(issueKeys); start += bulkEditMaxIssues {
+		end := min(start+bulkEditMaxIssues, len(issueKeys))
+		chunk := issueKeys[start:end]
+
+		body := map[string]any{
+			"selectedIssueIdsOrKeys": chunk,
+			"selectedActions":        []string{field},
+			"editedFieldsInput": map[string]any{
+				"multipleVersionPickerFields": []map[string]any{{
+					"fieldId": field,
+					// Replace matches the per-issue update, which overwrites the field
+					"bulkEditMultiVersionPickerOption": "REPLACE",
+					"versions":                         []map[string]string{{"versionId": versionID}},
+				}},
+			},
+			"sendBulkNotification": false,
+		}
+		req, err := client.Transport.NewRequest(ctx, http.MethodPost, bulkEditPath, body)
+		if err != nil {
+			return fmt.Errorf("failed to create bulk edit request: %w", err)
+		}
+		resp, err := client.Transport.Do(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to submit bulk edit: %w", err)
+		}
+
+		var task struct {
+			TaskID string `json:"taskId"`
+		}
+		if err := client.Transport.DecodeResponse(resp, &task); err != nil {
+			return fmt.Errorf("failed to submit bulk edit: %w", err)
+		}
+		if task.TaskID == "" {
+			return fmt.Errorf("bulk edit returned no task ID")
+		}
+
+		progress, err := p.waitForBulkEdit(ctx, client, task.TaskID)
+		if err != nil {
+			return err
+		}
+		if progress.Status != "COMPLETE" {
+			return fmt.Errorf("bulk edit task %s ended with status %s", task.TaskID, progress.Status)
+		}
+		if failed := len(progress.FailedAccessibleIssues) + progress.InvalidOrInaccessibleIssueCount; failed > 0 {
+			return fmt.Errorf("bulk edit task %s failed for %d of %d issues", task.TaskID, failed, len(chunk))
+		}
+		if len(progress.ProcessedAccessibleIssues) != len(chunk) {
+			return fmt.Errorf("bulk edit task %s processed %d of %d issues", task.TaskID, len(progress.ProcessedAccessibleIssues), len(chunk))
+		}
+	}
+	return nil
+}
+
+// waitForBulkEdit polls a bulk edit task until it leaves the queued and running states.
+func (p *JiraPlugin) waitForBulkEdit(ctx context.Context, client *jira.Client, taskID string) (*bulkEditProgress, error) {
+	path := fmt.Sprintf("/rest/api/3/bulk/queue/%s", url.PathEscape(taskID))
+	for attempt := 0; attempt < bulkPollAttempts; attempt++ {
+		req, err := client.Transport.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bulk edit status request: %w", err)
+		}
+		resp, err := client.Transport.Do(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bulk edit status: %w", err)
+		}
+
+		var progress bulkEditProgress
+		if err := client.Transport.DecodeResponse(resp, &progress); err != nil {
+			return nil, fmt.Errorf("failed to get bulk edit status: %w", err)
+		}
+		if progress.Status != "ENQUEUED" && progress.Status != "RUNNING" {
+			return &progress, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(bulkPollInterval):
+		}
+	}
+	return nil, fmt.Errorf("bulk edit task %s did not finish", taskID)
+}
+
+// availableTransition is a workflow transition offered for an issue's current status.
+type availableTransition struct {
+	ID   string
+	Name string
+}
+
+// getTransitions returns the transitions available for an issue, in the order Jira lists them.
+// Names are not unique, so transitions are kept as a list rather than keyed by name.
+func (p *JiraPlugin) getTransitions(ctx context.Context, client *jira.Client, issueKey string) ([]availableTransition, error) {
+	transitions, err := client.Workflow.GetTransitions(ctx, issueKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transitions: %w", err)
+	}
+
+	available := make([]availableTransition, 0, len(transitions))
+	for _, t := range transitions {
+		available = append(available, availableTransition{ID: t.ID, Name: t.Name})
+	}
+	return available, nil
+}
+
+// findTransitionID looks up a transition ID by name, ignoring case. When several transitions
+// match, the first one Jira lists wins.
+func findTransitionID(transitions []availableTransition, transitionName string) string {
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, transitionName) {
+			return t.ID
+		}
+	}
+	return ""
+}
+
+// hasTransitionID reports whether transitionID is among the available transitions.
+func hasTransitionID(transitions []availableTransition, transitionID string) bool {
+	for _, t := range transitions {
+		if t.ID == transitionID {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionIDsByName maps each transition name to its ID for reporting. A name shared by
+// several transitions maps to the first one, matching findTransitionID.
+func transitionIDsByName(transitions []availableTransition) map[string]string {
+	ids := make(map[string]string, len(transitions))
+	for _, t := range transitions {
+		if _, ok := ids[t.Name]; !ok {
+			ids[t.Name] = t.ID
+		}
+	}
+	return ids
+}
+
+// transitionConfigured reports whether a transition name or ID is configured.
+func (c *Config) transitionConfigured() bool {
+	return c.TransitionName != "" || c.TransitionID != ""
+}
+
+// transitionTarget describes the configured transition for messages, e.g. "to 'Done'" or
+// "via transition ID 31 with resolution 'Fixed'".
+func (c *Config) transitionTarget() string {
+	target := fmt.Sprintf("to '%s'", c.TransitionName)
+	if c.TransitionID != "" {
+		target = fmt.Sprintf("via transition ID %s", c.TransitionID)
+	}
+	if c.TransitionResolution != "" {
+		target += fmt.Sprintf(" with resolution '%s'", c.TransitionResolution)
+	}
+	return target
+}
+
+// transitionSpec describes the transition applied to released issues.
+type transitionSpec struct {
+	// Name is looked up among the issue's transitions when ID is empty.
+	Name string
+	// ID is used instead of looking up Name when set.
+	ID string
+	// Resolution, when set, is sent as the transition's resolution field.
+	Resolution string
+}
+
+// transition returns the transition settings for the configuration.
+func (c *Config) transition() transitionSpec {
+	return transitionSpec{
+		Name:       c.TransitionName,
+		ID:         c.TransitionID,
+		Resolution: c.TransitionResolution,
+	}
+}
+
+// errTransitionUnavailable reports that the configured transition cannot be applied from an
+// issue's current status.
+var errTransitionUnavailable = errors.New("transition not available")
+
+// transitionIssue transitions an issue to a specified status. The transition, given by ID or
+// looked up by name, must be among the issue's available transitions; otherwise
+// errTransitionUnavailable is returned without posting it.
+func (p *JiraPlugin) transitionIssue(ctx context.Context, client *jira.Client, issueKey string, spec transitionSpec) error {
+	// Get available transitions for the issue
+	transitions, err := p.getTransitions(ctx, client, issueKey)
+	if err != nil {
+		return err
+	}
+
+	transitionID := spec.ID
+	if transitionID == "" {
+		transitionID = findTransitionID(transitions, spec.Name)
+	}
+	if transitionID == "" || !hasTransitionID(transitions, transitionID) {
+		return unavailableTransitionError(issueKey, spec, transitions)
+	}
+
+	// Post the transition directly: Issue.DoTransition drops the error body, which explains
+	// why Jira rejected a resolution and carries the status that isNotFound inspects
+	input := &issue.TransitionInput{Transition: &issue.Transition{ID: transitionID}}
+	if spec.Resolution != "" {
+		input.Fields = map[string]interface{}{"resolution": map[string]string{"name": spec.Resolution}}
+	}
+	path := fmt.Sprintf("/rest/api/3/issue/%s/transitions", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodPost, path, input)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to transition issue %s: %w", issueKey, err)
+	}
+	if resp.StatusCode < 300 {
+		_ = resp.Body.Close()
+		return nil
+	}
+	err = client.Transport.DecodeResponse(resp, &struct{}{})
+	var errResp *transport.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Errors["resolution"] != "" {
+		return fmt.Errorf("resolution '%s' rejected for issue %s: %s: %w", spec.Resolution, issueKey, errResp.Errors["resolution"], err)
+	}
+	return fmt.Errorf("failed to transition issue %s: %w", issueKey, err)
+}
+
+// addLabel adds a label to an issue, keeping its existing labels.
+func (p *JiraPlugin) addLabel(ctx context.Context, client *jira.Client, issueKey, label string) error {
+	// Issue.Update only sets fields, which would replace the issue's labels
+	body := map[string]any{
+		"update": map[string]any{
+			"labels": []map[string]string{{"add": label}},
+		},
+	}
+	path := fmt.Sprintf("/rest/api/3/issue/%s", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to label issue %s: %w", issueKey, err)
+	}
+	if resp.StatusCode < 300 {
+		_ = resp.Body.Close()
+		return nil
+	}
+	return fmt.Errorf("failed to label issue %s: %w", issueKey, client.Transport.DecodeResponse(resp, &struct{}{}))
+}
+
+// addComponent adds a component to an issue, keeping its existing components. Jira rejects
+// components that do not exist in the issue's project.
+func (p *JiraPlugin) addComponent(ctx context.Context, client *jira.Client, issueKey, component string) error {
+	// Issue.Update only sets fields, which would replace the issue's components
+	body := map[string]any{
+		"update": map[string]any{
+			"components": []map[string]any{{"add": map[string]string{"name": component}}},
+		},
+	}
+	path := fmt.Sprintf("/rest/api/3/issue/%s", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to set component on issue %s: %w", issueKey, err)
+	}
+	if resp.StatusCode < 300 {
+		_ = resp.Body.Close()
+		return nil
+	}
+	err = client.Transport.DecodeResponse(resp, &struct{}{})
+	var errResp *transport.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Errors["components"] != "" {
+		return fmt.Errorf("component '%s' does not exist in the project of issue %s: %s: %w", component, issueKey, errResp.Errors["components"], err)
+	}
+	return fmt.Errorf("failed to set component on issue %s: %w", issueKey, err)
+}
+
+// unavailableTransitionError describes a transition that is not available for an issue,
+// listing the transitions that are.
+func unavailableTransitionError(issueKey string, spec transitionSpec, transitions []availableTransition) error {
+	target := fmt.Sprintf("'%s'", spec.Name)
+	if spec.ID != "" {
+		target = "ID " + spec.ID
+	}
+	available := make([]string, 0, len(transitions))
+	for _, t := range transitions {
+		available = append(available, fmt.Sprintf("%s (%s)", t.Name, t.ID))
+	}
+	if len(available) == 0 {
+		available = append(available, "none")
+	}
+	return fmt.Errorf("%w: transition %s cannot be applied to issue %s from its current status (available: %s)",
+		errTransitionUnavailable, target, issueKey, strings.Join(available, ", "))
+}
+
+// addComment adds a comment to an issue and returns the new comment's ID.
+func (p *JiraPlugin) addComment(ctx context.Context, client *jira.Client, issueKey, body, format string, visibility *commentVisibility) (string, error) {
+	if visibility != nil {
+		return p.addRestrictedComment(ctx, client, issueKey, commentADF(body, format), visibility)
+	}
+
+	// Create ADF (Atlassian Document Format) from the comment text
+	comment, err := client.Issue.AddComment(ctx, issueKey, &issue.AddCommentInput{
+		Body: commentADF(body, format),
+	})
+	if err != nil {
+		return "", err
+	}
+	return comment.ID, nil
+}
+
+// Comment visibility types.
+const (
+	commentVisibilityRole  = "role"
+	commentVisibilityGroup = "group"
+)
+
+// commentVisibility is the visibility restriction of a comment.
+type commentVisibility struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// commentVisibility returns the configured comment restriction, or nil if comments are visible
+// to all users.
+func (c *Config) commentVisibility() *commentVisibility {
+	if c.CommentVisibilityType == "" || c.CommentVisibilityValue == "" {
+		return nil
+	}
+	return &commentVisibility{Type: c.CommentVisibilityType, Value: c.CommentVisibilityValue}
+}
+
+// commentVisibilityNote describes the comment restriction for dry-run messages, e.g.
+// " (visible to group 'jira-developers')", or returns "" if there is none.
+func (c *Config) commentVisibilityNote() string {
+	visibility := c.commentVisibility()
+	if visibility == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (visible to %s '%s')", visibility.Type, visibility.Value)
+}
+
+// addRestrictedComment posts a comment with a visibility restriction, which
+// Issue.AddCommentInput cannot carry.
+func (p *JiraPlugin) addRestrictedComment(ctx context.Context, client *jira.Client, issueKey string, body *issue.ADF, visibility *commentVisibility) (string, error) {
+	input := map[string]any{"body": body, "visibility": visibility}
+	path := fmt.Sprintf("/rest/api/3/issue/%s/comment", url.PathEscape(issueKey))
+	req, err := client.Transport.NewRequest(ctx, http.MethodPost, path, input)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	var comment issue.Comment
+	if err := client.Transport.DecodeResponse(resp, &comment); err != nil {
+		return "", fmt.Errorf("failed to add comment to %s: %w", issueKey, err)
+	}
+	return comment.ID, nil
+}
+
+// hasComment reports whether an issue already has a comment with the same text as body,
+// such as one posted by an earlier attempt at the same release.
+func (p *JiraPlugin) hasComment(ctx context.Context, client *jira.Client, issueKey, body, format string) (bool, error) {
+	comments, err := client.Issue.ListComments(ctx, issueKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to list comments: %w", err)
+	}
+	want := strings.TrimSpace(adfText(commentADF(body, format)))
+	for _, comment := range comments {
+		if strings.TrimSpace(adfText(comment.Body)) == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// threadPropertyKey is the issue property holding the release thread's root comment ID.
+const threadPropertyKey = "relicta.release-thread"
+
+// threadRootComment is the body of the root comment that release comments reference.
+const threadRootComment = "Release history - release comments on this issue reference this comment."
+
+// threadRoot returns the root comment ID for an issue, posting the root comment with the given
+// visibility on first use.
+func (p *JiraPlugin) threadRoot(ctx context.Context, client *jira.Client, issueKey string, visibility *commentVisibility) (string, error) {
+	path := fmt.Sprintf("/rest/api/3/issue/%s/properties/%s", url.PathEscape(issueKey), threadPropertyKey)
+
+	req, err := client.Transport.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Transport.Do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get thread property: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+	} else {
+		var prop struct {
+			Value struct {
+				CommentID string `json:"commentId"`
+			} `json:"value"`
+		}
+		if err := client.Transport.DecodeResponse(resp, &prop); err != nil {
+			return "", fmt.Errorf("failed to get thread property: %w", err)
+		}
+		if prop.Value.CommentID != "" {
+			return prop.Value.CommentID, nil
+		}
+	}
+
+	rootID, err := p.addComment(ctx, client, issueKey, threadRootComment, commentFormatText, visibility)
+	if err != nil {
+		return "", fmt.Errorf("failed to post root comment: %w", err)
+	}
+
+	req, err = client.Transport.NewRequest(ctx, http.MethodPut, path, map[string]string{"commentId": rootID})
+	if err != nil {
+		return "", err
+	}
+	resp, err = client.Transport.Do(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to store thread property: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to store thread property: HTTP %d", resp.StatusCode)
+	}
+
+	return rootID, nil
+}
+
+// issueBrowseURL returns the web link to an issue, or an empty string without a base URL.
+func issueBrowseURL(baseURL, issueKey string) string {
+	if baseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(baseURL, "/"), issueKey)
+}
+
+// versionBrowseURL returns the web link to a version's release page, or an empty string
+// without a base URL or version ID.
+func versionBrowseURL(baseURL, projectKey, versionID string) string {
+	if baseURL == "" || versionID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/projects/%s/versions/%s", strings.TrimSuffix(baseURL, "/"), projectKey, url.PathEscape(versionID))
+}
+
+// threadCommentURL returns a permalink to a comment on an issue, or an empty string without a base URL.
+func threadCommentURL(baseURL, issueKey, commentID string) string {
+	link := issueBrowseURL(baseURL, issueKey)
+	if link == "" {
+		return ""
+	}
+	return link + "?focusedCommentId=" + url.QueryEscape(commentID)
+}
+
+// renderComment builds a comment from a template using Go template actions and {placeholders},
+// honoring the configured release URL override.
+func (p *JiraPlugin) renderComment(cfg *Config, text string, releaseCtx plugin.ReleaseContext) (string, error) {
+	releaseURL := p.releaseURL(cfg, releaseCtx)
+	data := newCommentTemplateData(releaseCtx, p.extractIssueKeys(cfg, releaseCtx.Changes), releaseURL)
+	data.Date = timeNow().In(cfg.location()).Format("2006-01-02")
+	comment, err := executeCommentTemplate(text, data)
+	if err != nil {
+		return "", err
+	}
+	comment = strings.ReplaceAll(comment, "{release_url}", releaseURL)
+	comment = strings.ReplaceAll(comment, "{date}", data.Date)
+	return substitutePlaceholders(comment, releaseCtx), nil
+}
+
+// summaryComment builds the comment listing every issue in the release, linking the release
+// notes when a release URL is known.
+func (p *JiraPlugin) summaryComment(cfg *Config, releaseCtx plugin.ReleaseContext, versionName string, issueKeys []string, released bool) string {
+	header := fmt.Sprintf("Version %s (%s) includes %d issues", versionName, releasedLabel(released), len(issueKeys))
+	if releaseURL := p.releaseURL(cfg, releaseCtx); releaseURL != "" {
+		header += "\nRelease notes: " + releaseURL
+	}
+	items := make([]string, len(issueKeys))
+	for i, issueKey := range issueKeys {
+		items[i] = "- " + issueKey
+	}
+	return header + "\n\n" + strings.Join(items, "\n")
+}
+
+// issueComments holds the rendered comment bodies an issue can receive.
+type issueComments struct {
+	status   string
+	breaking string
+	revert   string
+	primary  string
+}
+
+// renderIssueComments renders the status, breaking-change and revert comment templates.
+func (p *JiraPlugin) renderIssueComments(cfg *Config, released bool, releaseCtx plugin.ReleaseContext) (issueComments, error) {
+	var comments issueComments
+	var err error
+	if comments.status, err = p.renderComment(cfg, cfg.statusCommentTemplate(released), releaseCtx); err != nil {
+		return comments, err
+	}
+	comments.breaking = comments.status
+	if cfg.BreakingCommentTemplate != "" {
+		if comments.breaking, err = p.renderComment(cfg, cfg.BreakingCommentTemplate, releaseCtx); err != nil {
+			return comments, err
+		}
+	}
+	if cfg.RevertCommentTemplate != "" {
+		if comments.revert, err = p.renderComment(cfg, cfg.RevertCommentTemplate, releaseCtx); err != nil {
+			return comments, err
+		}
+	}
+	if cfg.PrimaryCommentTemplate != "" {
+		if comments.primary, err = p.renderComment(cfg, cfg.PrimaryCommentTemplate, releaseCtx); err != nil {
+			return comments, err
+		}
+	}
+	return comments, nil
+}
+
+// releaseURL returns the value of {release_url}: the rendered release_url_template when set,
+// otherwise the repository URL from the release context.
+func (p *JiraPlugin) releaseURL(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	if cfg.ReleaseURLTemplate == "" {
+		return releaseCtx.RepositoryURL
+	}
+	return p.buildComment(cfg.ReleaseURLTemplate, releaseCtx)
+}
+
+// buildComment builds a comment from a template using Go template actions and {placeholders}.
+// A template that fails to render is used as plain text.
+func (p *JiraPlugin) buildComment(text string, releaseCtx plugin.ReleaseContext) string {
+	comment, err := executeCommentTemplate(text, newCommentTemplateData(releaseCtx, nil, releaseCtx.RepositoryURL))
+	if err != nil {
+		comment = text
+	}
+	return substitutePlaceholders(comment, releaseCtx)
+}
+
+// renderLabel renders the label added to released issues. Jira labels cannot contain spaces,
+// so runs of whitespace become a dash.
+func (p *JiraPlugin) renderLabel(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	if !cfg.AddLabels || cfg.LabelTemplate == "" {
+		return ""
+	}
+	return strings.Join(strings.Fields(p.buildComment(cfg.LabelTemplate, releaseCtx)), "-")
+}
+
+// renderComponent renders the name of the component set on released issues.
+func (p *JiraPlugin) renderComponent(cfg *Config, releaseCtx plugin.ReleaseContext) string {
+	if !cfg.SetComponent || cfg.ComponentName == "" {
+		return ""
+	}
+	return strings.TrimSpace(p.buildComment(cfg.ComponentName, releaseCtx))
+}
+
+// substitutePlaceholders replaces the release-wide {placeholder} values in a comment.
+func substitutePlaceholders(comment string, releaseCtx plugin.ReleaseContext) string {
+	comment = strings.ReplaceAll(comment, "{version}", releaseCtx.Version)
+	comment = strings.ReplaceAll(comment, "{tag}", releaseCtx.TagName)
+	comment = strings.ReplaceAll(comment, "{release_url}", releaseCtx.RepositoryURL)
+	comment = strings.ReplaceAll(comment, "{repository}", releaseCtx.RepositoryName)
+	return comment
+}
+
+// lookupIP resolves hostnames during base URL validation.
+// It is a variable so tests can point the plugin at a local mock server.
+var lookupIP = net.LookupIP
+
+// baseURLPolicy holds the configurable parts of base URL validation.
+type baseURLPolicy struct {
+	// ForbidIPHosts rejects IP literal hosts, even public ones.
+	ForbidIPHosts bool
+	// AllowPrivateHosts skips the localhost and private address checks for every host.
+	AllowPrivateHosts bool
+	// AllowedHosts lists hostnames and CIDRs that skip the localhost and private address checks.
+	AllowedHosts []string
+}
+
+// names reports whether host appears verbatim in the allowlist.
+func (p baseURLPolicy) names(host string) bool {
+	for _, entry := range p.AllowedHosts {
+		if strings.EqualFold(entry, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// listsHost reports whether host is named in the allowlist, or is an IP literal inside one of its CIDRs.
+func (p baseURLPolicy) listsHost(host string) bool {
+	if p.names(host) {
+		return true
+	}
+	ip := net.ParseIP(host)
+	for _, entry := range p.AllowedHosts {
+		if _, network, err := net.ParseCIDR(entry); err == nil && ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsPrivate reports whether host may use the private address ip.
+func (p baseURLPolicy) allowsPrivate(host string, ip net.IP) bool {
+	if p.AllowPrivateHosts || p.listsHost(host) {
+		return true
+	}
+	for _, entry := range p.AllowedHosts {
+		if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// baseURLPolicy returns the base URL validation policy for the configuration.
+func (c *Config) baseURLPolicy() baseURLPolicy {
+	return baseURLPolicy{
+		ForbidIPHosts:     c.ForbidIPBaseURL,
+		AllowPrivateHosts: c.AllowPrivateHosts,
+		AllowedHosts:      c.AllowedHosts,
+	}
+}
+
+// redactedHost replaces the Jira host in messages when redact_base_url is enabled.
+const redactedHost = "[redacted]"
+
+// redactResponse masks the base URL's host in a response's message, error and string outputs.
+// Requests still use the real URL; only what is reported back to the host is masked.
+func (c *Config) redactResponse(resp *plugin.ExecuteResponse) *plugin.ExecuteResponse {
+	if !c.RedactBaseURL || resp == nil {
+		return resp
+	}
+	parsed, err := url.Parse(c.BaseURL)
+	if err != nil || parsed.Host == "" {
+		return resp
+	}
+
+	redact := func(text string) string {
+		return strings.ReplaceAll(text, parsed.Host, redactedHost)
+	}
+	resp.Message = redact(resp.Message)
+	resp.Error = redact(resp.Error)
+	// Nested maps such as the per-project breakdown carry links too
+	var redactOutputs func(outputs map[string]any)
+	redactOutputs = func(outputs map[string]any) {
+		for key, value := range outputs {
+			switch v := value.(type) {
+			case string:
+				outputs[key] = redact(v)
+			case map[string]string:
+				for k, text := range v {
+					v[k] = redact(text)
+				}
+			case map[string]any:
+				redactOutputs(v)
+			}
+		}
+	}
+	redactOutputs(resp.Outputs)
+	return resp
+}
 
 // validateBaseURL validates the Jira base URL to prevent SSRF attacks.
 func validateBaseURL(rawURL string) error {
+	return validateBaseURLWithPolicy(rawURL, baseURLPolicy{})
+}
+
+// validateBaseURLWithPolicy validates the Jira base URL using the given policy.
+func validateBaseURLWithPolicy(rawURL string, policy baseURLPolicy) error {
 	if rawURL == "" {
 		return fmt.Errorf("base URL is required")
 	}
@@ -448,6 +4491,10 @@ func validateBaseURL(rawURL string) error {
 		return fmt.Errorf("invalid URL format: %w", err)
 	}
 
+	if policy.ForbidIPHosts && isIPLiteral(parsedURL.Hostname()) {
+		return fmt.Errorf("base_url must use a hostname, not an IP address")
+	}
+
 	// Check scheme - require HTTPS for production
 	if parsedURL.Scheme != "https" {
 		// Allow HTTP only for localhost (development)
@@ -470,23 +4517,13 @@ func validateBaseURL(rawURL string) error {
 	host := parsedURL.Hostname()
 
 	// Deny localhost/loopback (except in development with explicit localhost)
-	if parsedURL.Scheme == "https" {
+	if parsedURL.Scheme == "https" && !policy.AllowPrivateHosts && !policy.listsHost(host) {
 		if host == "localhost" || host == "127.0.0.1" || host == "[::1]" {
 			return fmt.Errorf("base_url cannot point to localhost")
 		}
 	}
 
-	// Resolve hostname and check for private IP addresses
-	ips, err := net.LookupIP(host)
-	if err == nil {
-		for _, ip := range ips {
-			if isPrivateIP(ip) {
-				return fmt.Errorf("base_url resolves to private/internal IP address (%s)", ip.String())
-			}
-		}
-	}
-
-	// Check for cloud metadata endpoints (common SSRF targets)
+	// Cloud metadata endpoints (common SSRF targets) are only reachable when listed explicitly
 	metadataHosts := []string{
 		"169.254.169.254",
 		"metadata.google.internal",
@@ -494,15 +4531,40 @@ func validateBaseURL(rawURL string) error {
 		"100.100.100.200",
 		"fd00:ec2::254",
 	}
-	for _, metaHost := range metadataHosts {
-		if strings.EqualFold(host, metaHost) {
-			return fmt.Errorf("base_url cannot point to cloud metadata service")
+	isMetadata := func(name string) bool {
+		for _, metaHost := range metadataHosts {
+			if strings.EqualFold(name, metaHost) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Resolve hostname and check for private IP addresses
+	ips, err := lookupIP(host)
+	if err == nil {
+		for _, ip := range ips {
+			if isPrivateIP(ip) && !policy.allowsPrivate(host, ip) {
+				return fmt.Errorf("base_url resolves to private/internal IP address (%s)", ip.String())
+			}
+			if isMetadata(ip.String()) && !policy.names(host) && !policy.names(ip.String()) {
+				return fmt.Errorf("base_url cannot point to cloud metadata service")
+			}
 		}
 	}
 
+	if isMetadata(host) && !policy.names(host) {
+		return fmt.Errorf("base_url cannot point to cloud metadata service")
+	}
+
 	return nil
 }
 
+// isIPLiteral reports whether a URL host is an IP address rather than a DNS name.
+func isIPLiteral(host string) bool {
+	return net.ParseIP(host) != nil
+}
+
 // isPrivateIP checks if an IP address is private/internal.
 func isPrivateIP(ip net.IP) bool {
 	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
@@ -549,61 +4611,170 @@ func isPrivateIP(ip net.IP) bool {
 	return false
 }
 
+// Environment variables consulted for credentials missing from the config, in order of precedence.
+var (
+	usernameEnvVars = []string{"JIRA_USERNAME", "JIRA_EMAIL"}
+	tokenEnvVars    = []string{"JIRA_TOKEN", "JIRA_API_TOKEN", "JIRA_PAT"}
+)
+
+// Authentication schemes accepted by auth_type.
+const (
+	authTypeBasic  = "basic"
+	authTypeBearer = "bearer"
+)
+
+// credentials are the Jira username and API token, along with the source each came from:
+// "config", "env:<NAME>", or empty when the value was not found.
+type credentials struct {
+	Username       string
+	Token          string
+	UsernameSource string
+	TokenSource    string
+}
+
+// sources reports where each credential came from, for debugging.
+func (c credentials) sources() map[string]string {
+	return map[string]string{
+		"username": c.UsernameSource,
+		"token":    c.TokenSource,
+	}
+}
+
+// resolveCredentials looks up the username and token. The plugin config takes precedence,
+// followed by the environment variables in the order listed in usernameEnvVars and tokenEnvVars.
+func resolveCredentials(cfg *Config) credentials {
+	var creds credentials
+	creds.Username, creds.UsernameSource = resolveCredential(cfg.Username, usernameEnvVars)
+	creds.Token, creds.TokenSource = resolveCredential(cfg.Token, tokenEnvVars)
+	return creds
+}
+
+// resolveCredential returns the config value if set, otherwise the first non-empty environment variable.
+func resolveCredential(configValue string, envVars []string) (string, string) {
+	if configValue != "" {
+		return configValue, "config"
+	}
+	for _, name := range envVars {
+		if v := os.Getenv(name); v != "" {
+			return v, "env:" + name
+		}
+	}
+	return "", ""
+}
+
 // getClient creates a Jira client using jirasdk.
-func (p *JiraPlugin) getClient(cfg *Config) (*jira.Client, error) {
+// Additional middlewares are applied outside the plugin's default ones. The limiter, which
+// may be nil, sits inside the retries so that every attempt of a write takes a slot.
+func (p *JiraPlugin) getClient(cfg *Config, limiter *requestLimiter, middlewares ...transport.Middleware) (*jira.Client, error) {
 	baseURL := cfg.BaseURL
 	if baseURL == "" {
 		return nil, fmt.Errorf("jira base URL is required")
 	}
 
 	// Validate URL for SSRF protection
-	if err := validateBaseURL(baseURL); err != nil {
+	if err := validateBaseURLWithPolicy(baseURL, cfg.baseURLPolicy()); err != nil {
 		return nil, fmt.Errorf("base_url validation failed: %w", err)
 	}
 
 	// Ensure URL doesn't have trailing slash
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
-	username := cfg.Username
-	if username == "" {
-		username = os.Getenv("JIRA_USERNAME")
-	}
-	if username == "" {
-		username = os.Getenv("JIRA_EMAIL")
-	}
+	creds := resolveCredentials(cfg)
+	username, token := creds.Username, creds.Token
 
-	token := cfg.Token
-	if token == "" {
-		token = os.Getenv("JIRA_TOKEN")
-	}
-	if token == "" {
-		token = os.Getenv("JIRA_API_TOKEN")
+	authOpt := jira.WithAPIToken(username, token)
+	if cfg.AuthType == authTypeBearer {
+		if token == "" {
+			return nil, fmt.Errorf("jira token is required for bearer auth (set JIRA_TOKEN/JIRA_API_TOKEN/JIRA_PAT env vars or configure in plugin)")
+		}
+		authOpt = jira.WithPAT(token)
+	} else if username == "" || token == "" {
+		return nil, fmt.Errorf("jira username and token are required (set JIRA_USERNAME/JIRA_EMAIL and JIRA_TOKEN/JIRA_API_TOKEN/JIRA_PAT env vars or configure in plugin)")
 	}
 
-	if username == "" || token == "" {
-		return nil, fmt.Errorf("jira username and token are required (set JIRA_USERNAME/JIRA_EMAIL and JIRA_TOKEN/JIRA_API_TOKEN env vars or configure in plugin)")
+	certs, err := loadClientCertificate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := proxyURL(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create client using jirasdk's functional options pattern
-	client, err := jira.NewClient(
+	opts := []jira.Option{
 		jira.WithBaseURL(baseURL),
-		jira.WithAPIToken(username, token),
-		jira.WithTimeout(30*time.Second),
-		jira.WithMaxRetries(3),
+		authOpt,
+		jira.WithHTTPClient(newHTTPClient(time.Duration(cfg.TimeoutSeconds)*time.Second, certs, proxy)),
+		jira.WithUserAgent(cfg.userAgent()),
+		// Retries are handled by retryMiddleware so network errors can be classified
+		jira.WithMaxRetries(0),
+	}
+	for _, mw := range middlewares {
+		opts = append(opts, jira.WithMiddleware(mw))
+	}
+	logger := cfg.logger()
+	opts = append(opts,
+		jira.WithMiddleware(contentTypeMiddleware()),
+		jira.WithMiddleware((&credentialExpiry{}).middleware()),
+		jira.WithMiddleware(retryMiddleware(cfg.MaxRetries)),
+		jira.WithMiddleware(limiter.middleware()),
+		jira.WithMiddleware(loggingMiddleware(logger)),
+		jira.WithMiddleware(newAPIVersionSelector(cfg, logger).middleware()),
 	)
+
+	client, err := jira.NewClient(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Jira client: %w", err)
 	}
+	logger.Debug("created Jira client", "auth_type", cfg.AuthType, "username_source", creds.UsernameSource,
+		"token_source", creds.TokenSource, "timeout_seconds", cfg.TimeoutSeconds, "max_retries", cfg.MaxRetries, "api_version", cfg.APIVersion)
 
 	return client, nil
 }
 
+// defaultUserAgent identifies the plugin in Jira's request logs.
+const defaultUserAgent = "relicta-jira-plugin/" + pluginVersion
+
+// userAgent returns the User-Agent header of Jira requests.
+func (c *Config) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+	return defaultUserAgent
+}
+
+// concurrency returns the number of issues processed at once. With fail_fast, issues are
+// processed one by one so that nothing after the first failing issue is touched.
+func (c *Config) concurrency() int {
+	if c.FailFast {
+		return 1
+	}
+	return c.Concurrency
+}
+
 // parseConfig parses the plugin configuration.
 func (p *JiraPlugin) parseConfig(raw map[string]any) *Config {
 	cfg := &Config{
-		CreateVersion:   true,
-		ReleaseVersion:  true,
-		AssociateIssues: true,
+		CreateVersion:        true,
+		ReleaseVersion:       true,
+		AssociateIssues:      true,
+		ClockSkewTolerance:   300,
+		StrictTransition:     true,
+		OnAmbiguousVersion:   ambiguousVersionFail,
+		MaxRetries:           3,
+		CommentOnClosed:      true,
+		CommentStrategy:      commentStrategyPerIssue,
+		CommentFormat:        commentFormatText,
+		IdempotentComments:   true,
+		PrimaryIssueSelector: primaryIssueFirstSeen,
+		OnExistingVersion:    existingVersionReuse,
+		AuthType:             authTypeBasic,
+		APIVersion:           apiVersionAuto,
+		VersionMatchMode:     versionMatchExact,
+		VersionField:         versionFieldFix,
+		TimeoutSeconds:       defaultTimeoutSeconds,
+		Concurrency:          defaultConcurrency,
 	}
 
 	if v, ok := raw["base_url"].(string); ok {
@@ -612,48 +4783,392 @@ func (p *JiraPlugin) parseConfig(raw map[string]any) *Config {
 	if v, ok := raw["username"].(string); ok {
 		cfg.Username = v
 	}
-	if v, ok := raw["token"].(string); ok {
-		cfg.Token = v
+	if v, ok := raw["token"].(string); ok {
+		cfg.Token = v
+	}
+	if v, ok := raw["project_key"].(string); ok {
+		cfg.ProjectKey = v
+	}
+	if v, ok := raw["version_name"].(string); ok {
+		cfg.VersionName = v
+	}
+	if v, ok := raw["version_description"].(string); ok {
+		cfg.VersionDescription = v
+	}
+	if v, ok := raw["create_version"].(bool); ok {
+		cfg.CreateVersion = v
+	}
+	if v, ok := raw["release_version"].(bool); ok {
+		cfg.ReleaseVersion = v
+	}
+	if v, ok := raw["archive_previous_version"].(bool); ok {
+		cfg.ArchivePreviousVersion = v
+	}
+	if v, ok := raw["transition_issues"].(bool); ok {
+		cfg.TransitionIssues = v
+	}
+	if v, ok := raw["transition_name"].(string); ok {
+		cfg.TransitionName = v
+	}
+	if v, ok := raw["skip_invalid_transitions"].(bool); ok {
+		cfg.SkipInvalidTransitions = v
+	}
+	if v, ok := raw["transition_resolution"].(string); ok {
+		cfg.TransitionResolution = strings.TrimSpace(v)
+	}
+	if v, ok := raw["transition_id"].(string); ok {
+		cfg.TransitionID = strings.TrimSpace(v)
+	} else if v, ok := intValue(raw["transition_id"]); ok {
+		cfg.TransitionID = strconv.Itoa(v)
+	}
+	if v, ok := raw["add_labels"].(bool); ok {
+		cfg.AddLabels = v
+	}
+	if v, ok := raw["label_template"].(string); ok {
+		cfg.LabelTemplate = strings.TrimSpace(v)
+	}
+	if v, ok := raw["set_component"].(bool); ok {
+		cfg.SetComponent = v
+	}
+	if v, ok := raw["component_name"].(string); ok {
+		cfg.ComponentName = strings.TrimSpace(v)
+	}
+	if v, ok := raw["comment_on_success"].(bool); ok {
+		cfg.CommentOnSuccess = v
+	}
+	if v, ok := raw["success_comment_template"].(string); ok {
+		cfg.SuccessCommentTemplate = v
+	}
+	if v, ok := raw["transition_on_error"].(bool); ok {
+		cfg.TransitionOnError = v
+	}
+	if v, ok := raw["error_transition_name"].(string); ok {
+		cfg.ErrorTransitionName = strings.TrimSpace(v)
+	}
+	if v, ok := raw["error_comment_template"].(string); ok {
+		cfg.ErrorCommentTemplate = v
+	}
+	if v, ok := raw["comment_visibility_type"].(string); ok {
+		cfg.CommentVisibilityType = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v, ok := raw["comment_visibility_value"].(string); ok {
+		cfg.CommentVisibilityValue = strings.TrimSpace(v)
+	}
+	if v, ok := raw["add_comment"].(bool); ok {
+		cfg.AddComment = v
+	}
+	if v, ok := raw["comment_template"].(string); ok {
+		cfg.CommentTemplate = v
+	}
+	if v, ok := raw["breaking_comment_template"].(string); ok {
+		cfg.BreakingCommentTemplate = v
+	}
+	if v, ok := raw["revert_comment_template"].(string); ok {
+		cfg.RevertCommentTemplate = v
+	}
+	if v, ok := raw["created_comment_template"].(string); ok {
+		cfg.CreatedCommentTemplate = v
+	}
+	if v, ok := raw["released_comment_template"].(string); ok {
+		cfg.ReleasedCommentTemplate = v
+	}
+	if v, ok := raw["issue_source"].(string); ok && v != "" {
+		cfg.IssueSource = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v, ok := raw["jql_query"].(string); ok {
+		cfg.JQLQuery = strings.TrimSpace(v)
+	}
+	if v, ok := raw["issue_pattern"].(string); ok {
+		cfg.IssuePattern = v
+	}
+	if v, ok := stringList(raw["issue_patterns"]); ok {
+		for _, pattern := range v {
+			if pattern != "" {
+				cfg.IssuePatterns = append(cfg.IssuePatterns, pattern)
+			}
+		}
+	}
+	if v, ok := stringList(raw["issue_categories"]); ok {
+		for _, category := range v {
+			if category = strings.ToLower(strings.TrimSpace(category)); category != "" {
+				cfg.IssueCategories = append(cfg.IssueCategories, category)
+			}
+		}
+	}
+	if v, ok := raw["associate_issues"].(bool); ok {
+		cfg.AssociateIssues = v
+	}
+	if v, ok := raw["dry_run_verify"].(bool); ok {
+		cfg.DryRunVerify = v
+	}
+	if v, ok := intValue(raw["clock_skew_tolerance_seconds"]); ok && v >= 0 {
+		cfg.ClockSkewTolerance = v
+	}
+	if v, ok := raw["verify_permissions"].(bool); ok {
+		cfg.VerifyPermissions = v
+	}
+	if v, ok := raw["release_url_template"].(string); ok {
+		cfg.ReleaseURLTemplate = v
+	}
+	if v, ok := raw["skip_closed_board_issues"].(bool); ok {
+		cfg.SkipClosedBoardIssues = v
+	}
+	if v, ok := raw["dry_run_versions"].(bool); ok {
+		cfg.DryRunVersions = &v
+	}
+	if v, ok := raw["dry_run_associations"].(bool); ok {
+		cfg.DryRunAssociations = &v
+	}
+	if v, ok := raw["dry_run_transitions"].(bool); ok {
+		cfg.DryRunTransitions = &v
+	}
+	if v, ok := raw["dry_run_comments"].(bool); ok {
+		cfg.DryRunComments = &v
+	}
+	if v, ok := raw["thread_under_root"].(bool); ok {
+		cfg.ThreadUnderRoot = v
+	}
+	if v, ok := raw["forbid_ip_base_url"].(bool); ok {
+		cfg.ForbidIPBaseURL = v
+	}
+	if v, ok := raw["follow_moved_issues"].(bool); ok {
+		cfg.FollowMovedIssues = v
+	}
+	if v, ok := raw["strict_transition"].(bool); ok {
+		cfg.StrictTransition = v
+	}
+	if v, ok := intValue(raw["bulk_associate_threshold"]); ok && v >= 0 {
+		cfg.BulkAssociateThreshold = v
+	}
+	if v, ok := raw["redact_base_url"].(bool); ok {
+		cfg.RedactBaseURL = v
+	}
+	if v, ok := raw["on_ambiguous_version"].(string); ok && v != "" {
+		cfg.OnAmbiguousVersion = v
+	}
+	if v, ok := raw["capture_snapshot"].(bool); ok {
+		cfg.CaptureSnapshot = v
+	}
+	if v, ok := raw["auto_create_missing_version"].(bool); ok {
+		cfg.AutoCreateMissingVersion = v
+	}
+	if v, ok := raw["normalize_comment_unicode"].(bool); ok {
+		cfg.NormalizeCommentUnicode = v
+	}
+	if v, ok := raw["strip_version_prefix"].(bool); ok {
+		cfg.StripVersionPrefix = v
+	}
+	if v, ok := raw["webhook_secret"].(string); ok {
+		cfg.WebhookSecret = v
+	}
+	if v, ok := raw["verbose_message"].(bool); ok {
+		cfg.VerboseMessage = v
+	}
+	if v, ok := raw["read_only"].(bool); ok {
+		cfg.ReadOnly = v
+	}
+	if v, ok := raw["create_version_only_if_issues"].(bool); ok {
+		cfg.CreateVersionOnlyIfIssues = v
+	}
+	if v, ok := intValue(raw["max_retries"]); ok && v >= 0 {
+		cfg.MaxRetries = v
+	}
+	if v, ok := raw["comment_on_closed"].(bool); ok {
+		cfg.CommentOnClosed = v
+	}
+	if v, ok := raw["on_existing_version"].(string); ok && v != "" {
+		cfg.OnExistingVersion = v
+	}
+	if v, ok := raw["no_issues_comment"].(string); ok {
+		cfg.NoIssuesComment = v
+	}
+	if v, ok := raw["no_issues_issue"].(string); ok {
+		cfg.NoIssuesIssue = strings.ToUpper(strings.TrimSpace(v))
+	}
+	if v, ok := raw["client_cert_file"].(string); ok {
+		cfg.ClientCertFile = v
+	}
+	if v, ok := raw["client_key_file"].(string); ok {
+		cfg.ClientKeyFile = v
+	}
+	if v, ok := raw["proxy_url"].(string); ok {
+		cfg.ProxyURL = strings.TrimSpace(v)
+	}
+	if v, ok := raw["auth_type"].(string); ok && v != "" {
+		cfg.AuthType = strings.ToLower(v)
+	}
+	if v, ok := intValue(raw["api_version"]); ok {
+		cfg.APIVersion = strconv.Itoa(v)
+	} else if v, ok := raw["api_version"].(string); ok && v != "" {
+		cfg.APIVersion = strings.ToLower(strings.TrimSpace(v))
+	}
+	if v, ok := raw["allow_private_hosts"].(bool); ok {
+		cfg.AllowPrivateHosts = v
+	}
+	if v, ok := stringList(raw["allowed_hosts"]); ok {
+		cfg.AllowedHosts = v
+	}
+	if v, ok := raw["version_match_mode"].(string); ok && v != "" {
+		cfg.VersionMatchMode = v
+	}
+	if v, ok := raw["user_agent"].(string); ok {
+		cfg.UserAgent = strings.TrimSpace(v)
+	}
+	if v, ok := floatValue(raw["requests_per_second"]); ok && v >= 0 {
+		cfg.RequestsPerSecond = v
+	}
+	if v, ok := raw["require_issues"].(bool); ok {
+		cfg.RequireIssues = v
+	}
+	if v, ok := raw["keyword_mode"].(bool); ok {
+		cfg.KeywordMode = v
+	}
+	if v, ok := stringList(raw["keywords"]); ok {
+		for _, keyword := range v {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				cfg.Keywords = append(cfg.Keywords, keyword)
+			}
+		}
+	}
+	if v, ok := intValue(raw["concurrency"]); ok && v > 0 {
+		cfg.Concurrency = min(v, maxConcurrency)
+	}
+	if v, ok := intValue(raw["timeout_seconds"]); ok && v > 0 {
+		cfg.TimeoutSeconds = v
+	}
+	if v, ok := raw["fail_fast"].(bool); ok {
+		cfg.FailFast = v
+	}
+	if v, ok := raw["version_field"].(string); ok && v != "" {
+		cfg.VersionField = v
+	}
+	if v, ok := raw["disable_standard_denylist"].(bool); ok {
+		cfg.DisableStandardDenylist = v
+	}
+	if v, ok := raw["verify_connection"].(bool); ok {
+		cfg.VerifyConnection = v
+	}
+	if v, ok := stringList(raw["trailer_keys"]); ok {
+		cfg.TrailerKeys = v
+	}
+	if v, ok := intValue(raw["version_visibility_timeout_seconds"]); ok && v >= 0 {
+		cfg.VersionVisibilityTimeout = v
+	}
+	if v, ok := intValue(raw["max_keys_per_commit"]); ok && v >= 0 {
+		cfg.MaxKeysPerCommit = v
+	}
+	if v, ok := raw["timezone"].(string); ok {
+		cfg.Timezone = strings.TrimSpace(v)
+	}
+	if v, ok := raw["infer_project_from_issues"].(bool); ok {
+		cfg.InferProjectFromIssues = v
+	}
+	if v, ok := raw["normalize_separators"].(bool); ok {
+		cfg.NormalizeSeparators = v
 	}
-	if v, ok := raw["project_key"].(string); ok {
-		cfg.ProjectKey = v
+	if v, ok := raw["log_level"].(string); ok {
+		cfg.LogLevel = strings.ToLower(strings.TrimSpace(v))
 	}
-	if v, ok := raw["version_name"].(string); ok {
-		cfg.VersionName = v
+	if v, ok := raw["version_start_date"].(string); ok {
+		cfg.VersionStartDate = strings.TrimSpace(v)
 	}
-	if v, ok := raw["version_description"].(string); ok {
-		cfg.VersionDescription = v
+	if v, ok := raw["release_date"].(string); ok {
+		cfg.ReleaseDate = strings.TrimSpace(v)
 	}
-	if v, ok := raw["create_version"].(bool); ok {
-		cfg.CreateVersion = v
+	if v, ok := raw["primary_comment_template"].(string); ok {
+		cfg.PrimaryCommentTemplate = v
 	}
-	if v, ok := raw["release_version"].(bool); ok {
-		cfg.ReleaseVersion = v
+	if v, ok := raw["primary_issue_selector"].(string); ok && v != "" {
+		cfg.PrimaryIssueSelector = v
 	}
-	if v, ok := raw["transition_issues"].(bool); ok {
-		cfg.TransitionIssues = v
+	if v, ok := raw["idempotent_comments"].(bool); ok {
+		cfg.IdempotentComments = v
 	}
-	if v, ok := raw["transition_name"].(string); ok {
-		cfg.TransitionName = v
+	if v, ok := raw["comment_format"].(string); ok && v != "" {
+		cfg.CommentFormat = v
 	}
-	if v, ok := raw["add_comment"].(bool); ok {
-		cfg.AddComment = v
+	if v, ok := raw["comment_strategy"].(string); ok && v != "" {
+		cfg.CommentStrategy = v
 	}
-	if v, ok := raw["comment_template"].(string); ok {
-		cfg.CommentTemplate = v
+	if v, ok := raw["summary_comment_issue"].(string); ok {
+		cfg.SummaryCommentIssue = strings.ToUpper(strings.TrimSpace(v))
 	}
-	if v, ok := raw["issue_pattern"].(string); ok {
-		cfg.IssuePattern = v
+	if v, ok := stringList(raw["project_keys"]); ok && len(v) > 0 {
+		cfg.ProjectKeys = projectKeyList(cfg.ProjectKey, v)
+		if cfg.ProjectKey == "" && len(cfg.ProjectKeys) > 0 {
+			cfg.ProjectKey = cfg.ProjectKeys[0]
+		}
 	}
-	if v, ok := raw["associate_issues"].(bool); ok {
-		cfg.AssociateIssues = v
+	if v, ok := raw["instance_key_map"].(map[string]any); ok {
+		cfg.InstanceKeyMap = make(map[string]string, len(v))
+		for prefix, baseURL := range v {
+			if s, ok := baseURL.(string); ok && s != "" {
+				cfg.InstanceKeyMap[strings.ToUpper(prefix)] = s
+			}
+		}
+	}
+	if !cfg.StrictTransition && !cfg.transitionConfigured() {
+		cfg.TransitionIssues = false
 	}
 
 	return cfg
 }
 
+// projectKeyList normalizes the configured project keys, listing projectKey first when it is
+// set so it stays the primary project.
+func projectKeyList(projectKey string, keys []string) []string {
+	var list []string
+	for _, key := range append([]string{projectKey}, keys...) {
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if key != "" && !containsString(list, key) {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// intValue converts a numeric config value to an int, truncating fractions.
+// JSON-decoded configs carry numbers as float64.
+func intValue(v any) (int, bool) {
+	n, ok := floatValue(v)
+	return int(n), ok
+}
+
+// floatValue converts a configured number, as decoded from JSON or YAML. It backs intValue,
+// so both accept the same numeric types.
+func floatValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// stringList converts a configured list of strings, as decoded from JSON or YAML.
+func stringList(v any) ([]string, bool) {
+	switch list := v.(type) {
+	case []string:
+		return list, true
+	case []any:
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
 // Validate validates the plugin configuration.
-func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
+func (p *JiraPlugin) Validate(ctx context.Context, config map[string]any) (*plugin.ValidateResponse, error) {
 	var errors []plugin.ValidationError
 
 	// Base URL is required
@@ -673,6 +5188,14 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 			Message: "base_url must start with http:// or https://",
 			Code:    "format",
 		})
+	} else if forbidIP, ok := config["forbid_ip_base_url"].(bool); ok && forbidIP {
+		if parsed, err := url.Parse(baseURL); err == nil && isIPLiteral(parsed.Hostname()) {
+			errors = append(errors, plugin.ValidationError{
+				Field:   "base_url",
+				Message: "base_url must use a hostname, not an IP address (forbid_ip_base_url is enabled)",
+				Code:    "format",
+			})
+		}
 	}
 
 	// Project key is required
@@ -680,7 +5203,8 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 	if v, ok := config["project_key"].(string); ok {
 		projectKey = v
 	}
-	if projectKey == "" {
+	inferProject, _ := config["infer_project_from_issues"].(bool)
+	if projectKeys, _ := stringList(config["project_keys"]); projectKey == "" && len(projectKeyList("", projectKeys)) == 0 && !inferProject {
 		errors = append(errors, plugin.ValidationError{
 			Field:   "project_key",
 			Message: "Jira project key is required",
@@ -689,27 +5213,10 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 	}
 
 	// Token/credentials check
-	token := ""
-	if v, ok := config["token"].(string); ok {
-		token = v
-	}
-	if token == "" {
-		token = os.Getenv("JIRA_TOKEN")
-	}
-	if token == "" {
-		token = os.Getenv("JIRA_API_TOKEN")
-	}
-
-	username := ""
-	if v, ok := config["username"].(string); ok {
-		username = v
-	}
-	if username == "" {
-		username = os.Getenv("JIRA_USERNAME")
-	}
-	if username == "" {
-		username = os.Getenv("JIRA_EMAIL")
-	}
+	parsed := p.parseConfig(config)
+	creds := resolveCredentials(parsed)
+	token := creds.Token
+	username := creds.Username
 
 	if token == "" {
 		errors = append(errors, plugin.ValidationError{
@@ -718,7 +5225,8 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 			Code:    "required",
 		})
 	}
-	if username == "" {
+	// Bearer personal access tokens identify the user on their own
+	if username == "" && parsed.AuthType != authTypeBearer {
 		errors = append(errors, plugin.ValidationError{
 			Field:   "username",
 			Message: "Jira username is required (set JIRA_USERNAME env var or configure username)",
@@ -726,6 +5234,69 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 		})
 	}
 
+	// Reject credential fields that conflict with each other, since only one set would be used
+	errors = append(errors, conflictingCredentials(config, parsed)...)
+
+	// Validate allowed_hosts CIDR entries
+	for _, entry := range parsed.AllowedHosts {
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				errors = append(errors, plugin.ValidationError{
+					Field:   "allowed_hosts",
+					Message: fmt.Sprintf("invalid CIDR %q in allowed_hosts: %v", entry, err),
+					Code:    "format",
+				})
+			}
+		}
+	}
+
+	// Validate auth_type is a known scheme
+	switch parsed.AuthType {
+	case authTypeBasic, authTypeBearer:
+	default:
+		errors = append(errors, plugin.ValidationError{
+			Field:   "auth_type",
+			Message: "auth_type must be one of: basic, bearer",
+			Code:    "enum",
+		})
+	}
+
+	// Validate api_version is a known API version, and that ADF formatting is not requested on v2
+	switch parsed.APIVersion {
+	case apiVersionAuto, apiVersion2, apiVersion3:
+	default:
+		errors = append(errors, plugin.ValidationError{
+			Field:   "api_version",
+			Message: "api_version must be one of: auto, 2, 3",
+			Code:    "enum",
+		})
+	}
+	if parsed.APIVersion == apiVersion2 && parsed.CommentFormat == commentFormatADF {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "comment_format",
+			Message: "comment_format adf requires api_version 3; the v2 API takes comments as plain text",
+			Code:    "conflict",
+		})
+	}
+
+	// Validate the mTLS client certificate can be loaded
+	if _, err := loadClientCertificate(parsed); err != nil {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "client_cert_file",
+			Message: err.Error(),
+			Code:    "format",
+		})
+	}
+
+	// Validate the proxy URL format
+	if _, err := proxyURL(parsed); err != nil {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "proxy_url",
+			Message: err.Error(),
+			Code:    "format",
+		})
+	}
+
 	// Validate issue pattern if provided
 	if pattern, ok := config["issue_pattern"].(string); ok && pattern != "" {
 		_, err := regexp.Compile(pattern)
@@ -737,22 +5308,250 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 			})
 		}
 	}
+	// Validate each of issue_patterns, naming the offending entry
+	if patterns, ok := stringList(config["issue_patterns"]); ok {
+		for i, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errors = append(errors, plugin.ValidationError{
+					Field:   fmt.Sprintf("issue_patterns[%d]", i),
+					Message: fmt.Sprintf("Invalid regex pattern: %v", err),
+					Code:    "format",
+				})
+			}
+		}
+	}
+	// Validate each of issue_categories names a change category
+	if categories, ok := stringList(config["issue_categories"]); ok {
+		for i, category := range categories {
+			if !containsString(changeCategories, strings.ToLower(strings.TrimSpace(category))) {
+				errors = append(errors, plugin.ValidationError{
+					Field:   fmt.Sprintf("issue_categories[%d]", i),
+					Message: fmt.Sprintf("Unknown change category %q; expected one of %s", category, strings.Join(changeCategories, ", ")),
+					Code:    "enum",
+				})
+			}
+		}
+	}
 
-	// Validate transition_name is provided when transition_issues is true
+	// Validate transition_name or transition_id is provided when transition_issues is true,
+	// unless strict_transition is disabled and an empty name means no transition
+	strictTransition := true
+	if v, ok := config["strict_transition"].(bool); ok {
+		strictTransition = v
+	}
 	if transitionIssues, ok := config["transition_issues"].(bool); ok && transitionIssues {
-		transitionName := ""
-		if v, ok := config["transition_name"].(string); ok {
-			transitionName = v
-		}
-		if transitionName == "" {
+		switch {
+		case parsed.TransitionName != "" && parsed.TransitionID != "":
+			errors = append(errors, plugin.ValidationError{
+				Field:   "transition_id",
+				Message: "set either transition_name or transition_id, not both",
+				Code:    "format",
+			})
+		case !parsed.transitionConfigured() && strictTransition:
 			errors = append(errors, plugin.ValidationError{
 				Field:   "transition_name",
-				Message: "transition_name is required when transition_issues is true",
+				Message: "transition_name or transition_id is required when transition_issues is true",
 				Code:    "required",
 			})
 		}
 	}
 
+	// Validate instance_key_map base URLs
+	if instances, ok := config["instance_key_map"].(map[string]any); ok {
+		for prefix, value := range instances {
+			baseURL, _ := value.(string)
+			if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+				errors = append(errors, plugin.ValidationError{
+					Field:   "instance_key_map",
+					Message: fmt.Sprintf("base URL for %s must start with http:// or https://", prefix),
+					Code:    "format",
+				})
+			}
+		}
+	}
+
+	// Validate on_ambiguous_version is a known policy
+	if v, ok := config["on_ambiguous_version"].(string); ok && v != "" {
+		switch v {
+		case ambiguousVersionFail, ambiguousVersionPreferUnreleased, ambiguousVersionPreferNewest:
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "on_ambiguous_version",
+				Message: "on_ambiguous_version must be one of: fail, prefer_unreleased, prefer_newest",
+				Code:    "enum",
+			})
+		}
+	}
+
+	// Validate version_match_mode is a known mode
+	if v, ok := config["version_match_mode"].(string); ok && v != "" {
+		switch v {
+		case versionMatchExact, versionMatchContains, versionMatchPrefix:
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "version_match_mode",
+				Message: "version_match_mode must be one of: exact, contains, prefix",
+				Code:    "enum",
+			})
+		}
+	}
+
+	// Validate timeout_seconds stays within a sane bound
+	if v, ok := intValue(config["timeout_seconds"]); ok && v > maxTimeoutSeconds {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "timeout_seconds",
+			Message: fmt.Sprintf("timeout_seconds must be at most %d", maxTimeoutSeconds),
+			Code:    "range",
+		})
+	}
+
+	// Validate concurrency stays within a sane bound
+	if v, ok := intValue(config["concurrency"]); ok && (v < 1 || v > maxConcurrency) {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "concurrency",
+			Message: fmt.Sprintf("concurrency must be between 1 and %d", maxConcurrency),
+			Code:    "format",
+		})
+	}
+
+	// Validate version_visibility_timeout_seconds stays short
+	if v, ok := intValue(config["version_visibility_timeout_seconds"]); ok && v > maxVersionVisibilityTimeout {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "version_visibility_timeout_seconds",
+			Message: fmt.Sprintf("version_visibility_timeout_seconds must be at most %d", maxVersionVisibilityTimeout),
+			Code:    "format",
+		})
+	}
+
+	// Validate version_field is a known field
+	if v, ok := config["version_field"].(string); ok && v != "" {
+		switch v {
+		case versionFieldFix, versionFieldAffects:
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "version_field",
+				Message: "version_field must be one of: fix, affects",
+				Code:    "enum",
+			})
+		}
+	}
+
+	// Validate timezone names a loadable zone
+	if parsed.Timezone != "" {
+		if _, err := time.LoadLocation(parsed.Timezone); err != nil {
+			errors = append(errors, plugin.ValidationError{
+				Field:   "timezone",
+				Message: fmt.Sprintf("timezone must be an IANA time zone name (e.g. Europe/Berlin): %v", err),
+				Code:    "format",
+			})
+		}
+	}
+
+	// Validate release_date and version_start_date are dates or "today"
+	dates := map[string]string{}
+	for _, field := range []struct{ name, value string }{
+		{"release_date", parsed.ReleaseDate},
+		{"version_start_date", parsed.VersionStartDate},
+	} {
+		if field.value == "" || strings.EqualFold(field.value, releaseDateToday) {
+			continue
+		}
+		date, err := parseVersionDate(field.name, field.value, parsed.location())
+		if err != nil {
+			errors = append(errors, plugin.ValidationError{
+				Field:   field.name,
+				Message: err.Error(),
+				Code:    "format",
+			})
+			continue
+		}
+		dates[field.name] = date
+	}
+	// Jira rejects a version that starts after its release date
+	if start, release := dates["version_start_date"], dates["release_date"]; start != "" && release != "" && parsed.ReleaseVersion && start > release {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "version_start_date",
+			Message: fmt.Sprintf("version_start_date %s is after release_date %s", start, release),
+			Code:    "conflict",
+		})
+	}
+
+	// Validate no_issues_comment can be posted: a release without issues fails first with require_issues
+	if parsed.RequireIssues && parsed.NoIssuesComment != "" {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "no_issues_comment",
+			Message: "no_issues_comment is never posted when require_issues is true",
+			Code:    "conflict",
+		})
+	}
+
+	// Validate primary_issue_selector is a known selector
+	if v, ok := config["primary_issue_selector"].(string); ok && v != "" {
+		switch v {
+		case primaryIssueFirstSeen, primaryIssueLowestKey, primaryIssueMostRecent:
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "primary_issue_selector",
+				Message: "primary_issue_selector must be one of: first_seen, lowest_key, most_recent",
+				Code:    "enum",
+			})
+		}
+	}
+
+	// Validate log_level is a known level
+	switch parsed.LogLevel {
+	case "", logLevelDebug, logLevelInfo, logLevelWarn, logLevelError, logLevelOff:
+	default:
+		errors = append(errors, plugin.ValidationError{
+			Field:   "log_level",
+			Message: "log_level must be one of: debug, info, warn, error, off",
+			Code:    "enum",
+		})
+	}
+
+	// Validate comment_format is a known format
+	if v, ok := config["comment_format"].(string); ok && v != "" && v != commentFormatText && v != commentFormatADF {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "comment_format",
+			Message: "comment_format must be one of: text, adf",
+			Code:    "enum",
+		})
+	}
+
+	// Validate comment_strategy is a known strategy
+	if v, ok := config["comment_strategy"].(string); ok && v != "" {
+		switch v {
+		case commentStrategyPerIssue:
+		case commentStrategySummaryOnly, commentStrategyBoth:
+			if parsed.SummaryCommentIssue == "" {
+				errors = append(errors, plugin.ValidationError{
+					Field:   "summary_comment_issue",
+					Message: fmt.Sprintf("summary_comment_issue is required when comment_strategy is %s", v),
+					Code:    "required",
+				})
+			}
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "comment_strategy",
+				Message: "comment_strategy must be one of: per_issue, summary_only, both",
+				Code:    "enum",
+			})
+		}
+	}
+
+	// Validate on_existing_version is a known policy
+	if v, ok := config["on_existing_version"].(string); ok && v != "" {
+		switch v {
+		case existingVersionReuse, existingVersionSuffix:
+		default:
+			errors = append(errors, plugin.ValidationError{
+				Field:   "on_existing_version",
+				Message: "on_existing_version must be one of: reuse, suffix",
+				Code:    "enum",
+			})
+		}
+	}
+
 	// Validate comment_template is provided when add_comment is true
 	if addComment, ok := config["add_comment"].(bool); ok && addComment {
 		commentTemplate := ""
@@ -768,8 +5567,200 @@ func (p *JiraPlugin) Validate(_ context.Context, config map[string]any) (*plugin
 		}
 	}
 
+	// Comment visibility needs both a type and a value
+	switch {
+	case parsed.CommentVisibilityType != "" && parsed.CommentVisibilityValue == "":
+		errors = append(errors, plugin.ValidationError{
+			Field:   "comment_visibility_value",
+			Message: "comment_visibility_value is required when comment_visibility_type is set",
+			Code:    "required",
+		})
+	case parsed.CommentVisibilityType == "" && parsed.CommentVisibilityValue != "":
+		errors = append(errors, plugin.ValidationError{
+			Field:   "comment_visibility_type",
+			Message: "comment_visibility_type is required when comment_visibility_value is set",
+			Code:    "required",
+		})
+	}
+	switch parsed.CommentVisibilityType {
+	case "", commentVisibilityRole, commentVisibilityGroup:
+	default:
+		errors = append(errors, plugin.ValidationError{
+			Field:   "comment_visibility_type",
+			Message: "comment_visibility_type must be one of: role, group",
+			Code:    "enum",
+		})
+	}
+
+	// Validate the issue source
+	switch parsed.IssueSource {
+	case "", issueSourceCommits:
+	case issueSourceJQL:
+		if parsed.JQLQuery == "" {
+			errors = append(errors, plugin.ValidationError{
+				Field:   "jql_query",
+				Message: "jql_query is required when issue_source is jql",
+				Code:    "required",
+			})
+		}
+	default:
+		errors = append(errors, plugin.ValidationError{
+			Field:   "issue_source",
+			Message: "issue_source must be one of: commits, jql",
+			Code:    "enum",
+		})
+	}
+
+	// Validate label_template is provided when add_labels is true
+	if parsed.AddLabels && parsed.LabelTemplate == "" {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "label_template",
+			Message: "label_template is required when add_labels is true",
+			Code:    "required",
+		})
+	}
+
+	// Validate component_name is provided when set_component is true
+	if parsed.SetComponent && parsed.ComponentName == "" {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "component_name",
+			Message: "component_name is required when set_component is true",
+			Code:    "required",
+		})
+	}
+
+	// Validate success_comment_template is provided when comment_on_success is true
+	if parsed.CommentOnSuccess && strings.TrimSpace(parsed.SuccessCommentTemplate) == "" {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "success_comment_template",
+			Message: "success_comment_template is required when comment_on_success is true",
+			Code:    "required",
+		})
+	}
+
+	// Validate error_transition_name is provided when transition_on_error is true
+	if parsed.TransitionOnError && parsed.ErrorTransitionName == "" {
+		errors = append(errors, plugin.ValidationError{
+			Field:   "error_transition_name",
+			Message: "error_transition_name is required when transition_on_error is true",
+			Code:    "required",
+		})
+	}
+
+	// Validate Go template syntax in comment templates
+	for _, field := range []string{
+		"comment_template",
+		"created_comment_template",
+		"released_comment_template",
+		"breaking_comment_template",
+		"revert_comment_template",
+		"primary_comment_template",
+		"no_issues_comment",
+		"label_template",
+		"component_name",
+		"success_comment_template",
+		"error_comment_template",
+	} {
+		if v, ok := config[field].(string); ok && usesGoTemplate(v) {
+			if _, err := parseCommentTemplate(v); err != nil {
+				errors = append(errors, plugin.ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("invalid template: %v", err),
+					Code:    "format",
+				})
+			}
+		}
+	}
+
+	// Contact Jira only when asked, and only once the configuration is otherwise valid
+	if parsed.VerifyConnection && len(errors) == 0 {
+		errors = append(errors, p.verifyConnection(ctx, parsed)...)
+	}
+
 	return &plugin.ValidateResponse{
 		Valid:  len(errors) == 0,
 		Errors: errors,
 	}, nil
 }
+
+// conflictingCredentials reports credential fields that cannot be used together: a username
+// with Bearer auth, which sends only the token, and credentials embedded in base_url, which
+// would be silently replaced by the configured ones.
+func conflictingCredentials(config map[string]any, cfg *Config) []plugin.ValidationError {
+	var errors []plugin.ValidationError
+	if username, _ := config["username"].(string); username != "" && cfg.AuthType == authTypeBearer {
+		errors = append(errors, plugin.ValidationError{
+			Field: "username",
+			Message: "username cannot be combined with auth_type bearer: remove username to authenticate with the personal access token alone, " +
+				"or set auth_type to basic to authenticate with username and token",
+			Code: "conflict",
+		})
+	}
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.User != nil {
+		errors = append(errors, plugin.ValidationError{
+			Field: "base_url",
+			Message: "base_url cannot contain credentials: remove them from the URL and set username and token (auth_type basic), " +
+				"or token alone (auth_type bearer)",
+			Code: "conflict",
+		})
+	}
+	return errors
+}
+
+// verifyConnection checks that the credentials are accepted and the project is visible.
+// The client enforces the same base_url rules as a release.
+func (p *JiraPlugin) verifyConnection(ctx context.Context, cfg *Config) []plugin.ValidationError {
+	client, err := p.getClient(cfg, nil)
+	if err != nil {
+		return []plugin.ValidationError{{
+			Field:   "base_url",
+			Message: err.Error(),
+			Code:    "format",
+		}}
+	}
+
+	if _, err := client.User.GetMyself(ctx); err != nil {
+		return []plugin.ValidationError{{
+			Field:   "token",
+			Message: fmt.Sprintf("failed to authenticate with Jira: %v", err),
+			Code:    connectionErrorCode(err),
+		}}
+	}
+
+	var errors []plugin.ValidationError
+	for _, projectKey := range cfg.projects() {
+		if _, err := client.Project.Get(ctx, projectKey, nil); err != nil {
+			message := fmt.Sprintf("failed to access project %s: %v", projectKey, err)
+			if isNotFound(err) {
+				message = fmt.Sprintf("project %s does not exist or is not visible to this user", projectKey)
+			}
+			errors = append(errors, plugin.ValidationError{
+				Field:   projectKeyField(cfg),
+				Message: message,
+				Code:    connectionErrorCode(err),
+			})
+		}
+	}
+	return errors
+}
+
+// projectKeyField names the option the project keys were configured with.
+func projectKeyField(cfg *Config) string {
+	if len(cfg.ProjectKeys) > 0 {
+		return "project_keys"
+	}
+	return "project_key"
+}
+
+// connectionErrorCode classifies a failed verify_connection request: "auth" for rejected
+// credentials, "not_found" for a missing resource, otherwise "connection".
+func connectionErrorCode(err error) string {
+	switch {
+	case hasStatus(err, http.StatusUnauthorized), hasStatus(err, http.StatusForbidden):
+		return "auth"
+	case isNotFound(err):
+		return "not_found"
+	default:
+		return "connection"
+	}
+}
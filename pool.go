@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const (
+	// defaultConcurrency is the number of issues processed at once when concurrency is unset.
+	defaultConcurrency = 4
+	// maxConcurrency is the largest concurrency accepted by validation.
+	maxConcurrency = 32
+)
+
+// errNotStarted marks issues whose operation was never started because an earlier one
+// stopped the run.
+var errNotStarted = errors.New("operation not started")
+
+// notStarted returns results for n issues that have not been started yet.
+func notStarted(n int) []error {
+	results := make([]error, n)
+	for i := range results {
+		results[i] = errNotStarted
+	}
+	return results
+}
+
+// forEachIssue runs op on each issue with at most concurrency operations in flight and
+// returns their errors in issue order, so results can be recorded as if the issues were
+// processed one by one. Issues are started in order; once stop reports true for an error,
+// no further operations start and those already running finish. Issues left unstarted
+// report errNotStarted, or the context's error when ctx was canceled.
+func forEachIssue(ctx context.Context, concurrency int, issueKeys []string, op func(i int, issueKey string) error, stop func(err error) bool) []error {
+	results := notStarted(len(issueKeys))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stopped bool
+	)
+	slots := make(chan struct{}, concurrency)
+	for i, issueKey := range issueKeys {
+		slots <- struct{}{}
+		mu.Lock()
+		halted := stopped
+		mu.Unlock()
+		if halted {
+			<-slots
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			<-slots
+			for j := i; j < len(results); j++ {
+				results[j] = err
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			err := op(i, issueKey)
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = err
+			if err != nil && stop(err) {
+				stopped = true
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
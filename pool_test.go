@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// TestForEachIssue tests that issues run on a bounded pool and report results in issue order.
+func TestForEachIssue(t *testing.T) {
+	issueKeys := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4", "PROJ-5", "PROJ-6"}
+	errFailed := errors.New("failed")
+
+	t.Run("bounded", func(t *testing.T) {
+		var mu sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		errs := forEachIssue(context.Background(), 2, issueKeys, func(i int, issueKey string) error {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			if issueKey != issueKeys[i] {
+				t.Errorf("expected %s at index %d, got %s", issueKeys[i], i, issueKey)
+			}
+			if i%2 == 1 {
+				return fmt.Errorf("%s: %w", issueKey, errFailed)
+			}
+			return nil
+		}, func(error) bool { return false })
+
+		if maxInFlight != 2 {
+			t.Errorf("expected 2 operations in flight at most, got %d", maxInFlight)
+		}
+		for i, err := range errs {
+			if (i%2 == 1) != errors.Is(err, errFailed) || (err != nil && !strings.HasPrefix(err.Error(), issueKeys[i])) {
+				t.Errorf("unexpected result for %s: %v", issueKeys[i], err)
+			}
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		errs := forEachIssue(context.Background(), 1, issueKeys, func(i int, _ string) error {
+			if i == 2 {
+				return errFailed
+			}
+			return nil
+		}, func(err error) bool { return errors.Is(err, errFailed) })
+
+		want := []error{nil, nil, errFailed, errNotStarted, errNotStarted, errNotStarted}
+		for i, err := range errs {
+			if !errors.Is(err, want[i]) {
+				t.Errorf("expected %v for %s, got %v", want[i], issueKeys[i], err)
+			}
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		errs := forEachIssue(ctx, 1, issueKeys, func(i int, _ string) error {
+			if i == 1 {
+				cancel()
+			}
+			return nil
+		}, func(error) bool { return false })
+
+		for i, err := range errs {
+			if canceled := errors.Is(err, context.Canceled); canceled != (i > 1) {
+				t.Errorf("unexpected result for %s: %v", issueKeys[i], err)
+			}
+		}
+	})
+}
+
+// TestHandlePostPublishConcurrency tests that issues are commented on concurrently and that
+// the failures of individual issues are all reported.
+func TestHandlePostPublishConcurrency(t *testing.T) {
+	mock, server := newMockJira(t)
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mock.override = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/comment") {
+			return false
+		}
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if r.URL.Path == "/rest/api/3/issue/PROJ-2/comment" || r.URL.Path == "/rest/api/3/issue/PROJ-6/comment" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{"Comment rejected"}})
+			return true
+		}
+		return false
+	}
+
+	var commits []plugin.ConventionalCommit
+	for i := 1; i <= 8; i++ {
+		commits = append(commits, plugin.ConventionalCommit{Description: fmt.Sprintf("fix PROJ-%d", i)})
+	}
+	p := &JiraPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"base_url":         server.URL,
+			"project_key":      "PROJ",
+			"username":         "user@example.com",
+			"token":            "token",
+			"create_version":   false,
+			"release_version":  false,
+			"associate_issues": false,
+			"add_comment":      true,
+			"comment_template": "Released in {version}",
+			"concurrency":      3,
+		},
+		Context: plugin.ReleaseContext{
+			Version: "1.0.0",
+			Changes: &plugin.CategorizedChanges{Fixes: commits},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp == nil {
+		t.Fatal("expected a response")
+	}
+
+	if maxInFlight < 2 || maxInFlight > 3 {
+		t.Errorf("expected 2 or 3 comments in flight at most, got %d", maxInFlight)
+	}
+	for i := 1; i <= 8; i++ {
+		if n := mock.requestCount(http.MethodPost, fmt.Sprintf("/rest/api/3/issue/PROJ-%d/comment", i)); n != 1 {
+			t.Errorf("expected one comment request for PROJ-%d, got %d", i, n)
+		}
+	}
+	if resp.Success || !contains(resp.Error, "2/8 issues had failed operations: PROJ-2, PROJ-6") {
+		t.Errorf("expected both failures to be reported, got %q", resp.Error)
+	}
+	if !contains(resp.Message, "Added comments to 6/8 issues") {
+		t.Errorf("unexpected message %q", resp.Message)
+	}
+	succeeded, _ := resp.Outputs["succeeded_issues"].([]string)
+	if strings.Join(succeeded, ",") != "PROJ-1,PROJ-3,PROJ-4,PROJ-5,PROJ-7,PROJ-8" {
+		t.Errorf("expected succeeded_issues in issue order, got %v", resp.Outputs["succeeded_issues"])
+	}
+	issueErrors, _ := resp.Outputs["issue_errors"].(map[string]string)
+	if len(issueErrors) != 2 || !contains(issueErrors["PROJ-6"], "comment:") {
+		t.Errorf("expected comment errors for PROJ-2 and PROJ-6, got %v", resp.Outputs["issue_errors"])
+	}
+}
+
+// TestValidateConcurrency tests that concurrency stays within its bounds.
+func TestValidateConcurrency(t *testing.T) {
+	t.Setenv("JIRA_TOKEN", "token")
+	t.Setenv("JIRA_USERNAME", "user@example.com")
+
+	for _, tt := range []struct {
+		concurrency int
+		valid       bool
+	}{
+		{concurrency: 1, valid: true},
+		{concurrency: maxConcurrency, valid: true},
+		{concurrency: 0},
+		{concurrency: maxConcurrency + 1},
+	} {
+		p := &JiraPlugin{}
+		resp, err := p.Validate(context.Background(), map[string]any{
+			"base_url":    "https://company.atlassian.net",
+			"project_key": "PROJ",
+			"concurrency": tt.concurrency,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.valid != resp.Valid || (!tt.valid && resp.Errors[0].Field != "concurrency") {
+			t.Errorf("concurrency %d: expected valid=%v, got %+v", tt.concurrency, tt.valid, resp.Errors)
+		}
+	}
+}
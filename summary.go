@@ -0,0 +1,119 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// Actions counted in release_summary.
+const (
+	summaryVersions           = "versions" // created or found
+	summaryVersionsReleased   = "versions_released"
+	summaryIssuesAssociated   = "issues_associated"
+	summaryIssuesLabeled      = "issues_labeled"
+	summaryIssuesComponent    = "issues_component_set"
+	summaryIssuesTransitioned = "issues_transitioned"
+	summaryCommentsAdded      = "comments_added"
+)
+
+// releaseSummary records what PostPublish accomplished for a release so OnSuccess and OnError
+// can report it. Actions are counted as they succeed, so a run that fails partway still shows
+// what was done before the failure.
+type releaseSummary struct {
+	mu       sync.Mutex
+	version  string
+	issues   []string
+	dryRun   bool
+	actions  map[string]int
+	finished bool
+}
+
+// newReleaseSummary returns an empty summary for a PostPublish run.
+func newReleaseSummary(versionName string, issueKeys []string, dryRun bool) *releaseSummary {
+	return &releaseSummary{
+		version: versionName,
+		issues:  issueKeys,
+		dryRun:  dryRun,
+		actions: map[string]int{
+			summaryVersions:           0,
+			summaryVersionsReleased:   0,
+			summaryIssuesAssociated:   0,
+			summaryIssuesLabeled:      0,
+			summaryIssuesComponent:    0,
+			summaryIssuesTransitioned: 0,
+			summaryCommentsAdded:      0,
+		},
+	}
+}
+
+// add counts n more successful actions.
+func (s *releaseSummary) add(action string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[action] += n
+}
+
+// finish records that PostPublish ran to the end.
+func (s *releaseSummary) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished = true
+}
+
+// outputs returns the release_summary output.
+func (s *releaseSummary) outputs() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	actions := make(map[string]int, len(s.actions))
+	for action, n := range s.actions {
+		actions[action] = n
+	}
+	return map[string]any{
+		"version":   s.version,
+		"issues":    s.issues,
+		"actions":   actions,
+		"dry_run":   s.dryRun,
+		"completed": s.finished,
+		"source":    "post_publish",
+	}
+}
+
+// trackRelease registers the summary of a PostPublish run for the release version.
+func (p *JiraPlugin) trackRelease(releaseVersion string, summary *releaseSummary) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.summaries == nil {
+		p.summaries = make(map[string]*releaseSummary)
+	}
+	p.summaries[releaseVersion] = summary
+}
+
+// takeSummary returns and forgets the PostPublish summary of the release version, if any.
+func (p *JiraPlugin) takeSummary(releaseVersion string) *releaseSummary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	summary := p.summaries[releaseVersion]
+	delete(p.summaries, releaseVersion)
+	return summary
+}
+
+// releaseSummaryOutput returns the release_summary output for OnSuccess and OnError. When
+// PostPublish did not run in this process, the version and issues are derived from the
+// release context and no actions are reported.
+func (p *JiraPlugin) releaseSummaryOutput(cfg *Config, releaseCtx plugin.ReleaseContext) map[string]any {
+	if summary := p.takeSummary(releaseCtx.Version); summary != nil {
+		return summary.outputs()
+	}
+	issueKeys := p.extractIssueKeys(cfg, releaseCtx.Changes)
+	if issueKeys == nil {
+		issueKeys = []string{}
+	}
+	return map[string]any{
+		"version":   cfg.jiraVersionName(releaseCtx),
+		"issues":    issueKeys,
+		"actions":   map[string]int{},
+		"completed": false,
+		"source":    "derived",
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// TestReleaseSummary tests that OnSuccess and OnError report what PostPublish did.
+func TestReleaseSummary(t *testing.T) {
+	releaseCtx := plugin.ReleaseContext{
+		Version: "v1.0.0",
+		Changes: &plugin.CategorizedChanges{
+			Fixes: []plugin.ConventionalCommit{
+				{Description: "fix PROJ-1"},
+				{Description: "fix PROJ-2"},
+			},
+		},
+	}
+	execute := func(t *testing.T, p *JiraPlugin, hook plugin.Hook, config map[string]any) *plugin.ExecuteResponse {
+		t.Helper()
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{Hook: hook, Config: config, Context: releaseCtx})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+	summaryOf := func(t *testing.T, resp *plugin.ExecuteResponse) map[string]any {
+		t.Helper()
+		summary, ok := resp.Outputs["release_summary"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected release_summary output, got %v", resp.Outputs)
+		}
+		return summary
+	}
+
+	t.Run("on success", func(t *testing.T) {
+		_, server := newMockJira(t)
+		config := map[string]any{
+			"base_url":             server.URL,
+			"project_key":          "PROJ",
+			"username":             "user@example.com",
+			"token":                "token",
+			"strip_version_prefix": true,
+			"transition_issues":    true,
+			"transition_name":      "Done",
+			"add_comment":          true,
+			"comment_template":     "Released in {version}",
+		}
+
+		p := &JiraPlugin{}
+		if resp := execute(t, p, plugin.HookPostPublish, config); !resp.Success {
+			t.Fatalf("expected PostPublish to succeed, got error: %s", resp.Error)
+		}
+		resp := execute(t, p, plugin.HookOnSuccess, config)
+		if resp.Message != "Release successful - Jira integration acknowledged" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+
+		summary := summaryOf(t, resp)
+		if summary["version"] != "1.0.0" || summary["completed"] != true || summary["source"] != "post_publish" {
+			t.Errorf("unexpected summary %v", summary)
+		}
+		if issues, _ := summary["issues"].([]string); !reflect.DeepEqual(issues, []string{"PROJ-1", "PROJ-2"}) {
+			t.Errorf("expected issues [PROJ-1 PROJ-2], got %v", summary["issues"])
+		}
+		want := map[string]int{
+			summaryVersions:           1,
+			summaryVersionsReleased:   1,
+			summaryIssuesAssociated:   2,
+			summaryIssuesLabeled:      0,
+			summaryIssuesComponent:    0,
+			summaryIssuesTransitioned: 2,
+			summaryCommentsAdded:      2,
+		}
+		if actions, _ := summary["actions"].(map[string]int); !reflect.DeepEqual(actions, want) {
+			t.Errorf("expected actions %v, got %v", want, summary["actions"])
+		}
+
+		// The summary is reported once
+		if summary := summaryOf(t, execute(t, p, plugin.HookOnSuccess, config)); summary["source"] != "derived" {
+			t.Errorf("expected a derived summary after the first report, got %v", summary)
+		}
+	})
+
+	t.Run("on error", func(t *testing.T) {
+		mock, server := newMockJira(t)
+		mock.override = func(w http.ResponseWriter, r *http.Request) bool {
+			if r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/issue/PROJ-2/transitions" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"errorMessages": []string{"Field resolution is required"}})
+				return true
+			}
+			return false
+		}
+		config := map[string]any{
+			"base_url":          server.URL,
+			"project_key":       "PROJ",
+			"username":          "user@example.com",
+			"token":             "token",
+			"transition_issues": true,
+			"transition_name":   "Done",
+			"fail_fast":         true,
+		}
+
+		p := &JiraPlugin{}
+		if resp := execute(t, p, plugin.HookPostPublish, config); resp.Success {
+			t.Fatal("expected PostPublish to fail")
+		}
+		resp := execute(t, p, plugin.HookOnError, config)
+		if resp.Message != "Release failed - Jira integration acknowledged" {
+			t.Errorf("unexpected message %q", resp.Message)
+		}
+
+		summary := summaryOf(t, resp)
+		if summary["version"] != "v1.0.0" || summary["completed"] != false || summary["source"] != "post_publish" {
+			t.Errorf("unexpected summary %v", summary)
+		}
+		actions, _ := summary["actions"].(map[string]int)
+		if actions[summaryIssuesAssociated] != 2 || actions[summaryIssuesTransitioned] != 1 || actions[summaryCommentsAdded] != 0 {
+			t.Errorf("expected the work done before the failure, got %v", actions)
+		}
+	})
+
+	t.Run("derived", func(t *testing.T) {
+		p := &JiraPlugin{}
+		summary := summaryOf(t, execute(t, p, plugin.HookOnError, map[string]any{"project_key": "PROJ"}))
+
+		if summary["version"] != "v1.0.0" || summary["completed"] != false || summary["source"] != "derived" {
+			t.Errorf("unexpected summary %v", summary)
+		}
+		if issues, _ := summary["issues"].([]string); !reflect.DeepEqual(issues, []string{"PROJ-1", "PROJ-2"}) {
+			t.Errorf("expected issues [PROJ-1 PROJ-2], got %v", summary["issues"])
+		}
+		if actions, _ := summary["actions"].(map[string]int); len(actions) != 0 {
+			t.Errorf("expected no actions, got %v", actions)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// commentTemplateData is the data available to Go template syntax in comment templates,
+// e.g. {{.Version}} or {{range .Issues}}{{.}} {{end}}.
+type commentTemplateData struct {
+	Version       string
+	Tag           string
+	Repository    string
+	RepositoryURL string
+	// ReleaseURL is the value of {release_url}: the rendered release_url_template when set,
+	// otherwise the repository URL.
+	ReleaseURL string
+	// Date is the value of {date}: today in the configured timezone, as YYYY-MM-DD.
+	Date string
+	// Issues lists every issue key referenced by the release.
+	Issues []string
+	// Changes holds the release's commits by category.
+	Changes plugin.CategorizedChanges
+}
+
+// newCommentTemplateData builds the template data for a release.
+func newCommentTemplateData(releaseCtx plugin.ReleaseContext, issues []string, releaseURL string) commentTemplateData {
+	data := commentTemplateData{
+		Version:       releaseCtx.Version,
+		Tag:           releaseCtx.TagName,
+		Repository:    releaseCtx.RepositoryName,
+		RepositoryURL: releaseCtx.RepositoryURL,
+		ReleaseURL:    releaseURL,
+		Issues:        issues,
+	}
+	if releaseCtx.Changes != nil {
+		data.Changes = *releaseCtx.Changes
+	}
+	return data
+}
+
+// usesGoTemplate reports whether text contains Go template actions. Templates using only
+// {placeholder} syntax skip template parsing entirely.
+func usesGoTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// parseCommentTemplate parses the Go template actions in a comment template.
+func parseCommentTemplate(text string) (*template.Template, error) {
+	return template.New("comment").Parse(text)
+}
+
+// executeCommentTemplate renders the Go template actions in text. The legacy {placeholder}
+// syntax is left untouched for substitutePlaceholders, which must run afterwards so values
+// inserted here are never parsed as template code.
+func executeCommentTemplate(text string, data commentTemplateData) (string, error) {
+	if !usesGoTemplate(text) {
+		return text, nil
+	}
+	tmpl, err := parseCommentTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
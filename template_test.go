@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRenderCommentGoTemplate(t *testing.T) {
+	p := &JiraPlugin{}
+	releaseCtx := plugin.ReleaseContext{
+		Version:        "1.2.3",
+		TagName:        "v1.2.3",
+		RepositoryName: "app",
+		RepositoryURL:  "https://github.com/org/app",
+		Changes: &plugin.CategorizedChanges{
+			Features: []plugin.ConventionalCommit{{Description: "add export PROJ-1"}},
+			Fixes:    []plugin.ConventionalCommit{{Description: "fix crash PROJ-2"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "fields",
+			template: "{{.Repository}} {{.Version}} ({{.Tag}}) {{.RepositoryURL}}",
+			expected: "app 1.2.3 (v1.2.3) https://github.com/org/app",
+		},
+		{
+			name:     "issues_loop",
+			template: "Issues:{{range .Issues}} {{.}}{{end}}",
+			expected: "Issues: PROJ-1 PROJ-2",
+		},
+		{
+			name:     "conditional_on_changes",
+			template: "{{if .Changes.Fixes}}Includes {{len .Changes.Fixes}} fix{{else}}No fixes{{end}}",
+			expected: "Includes 1 fix",
+		},
+		{
+			name:     "mixed_syntax",
+			template: "Released {version} with {{len .Issues}} issues: {release_url}",
+			expected: "Released 1.2.3 with 2 issues: https://github.com/org/app",
+		},
+		{
+			name:     "legacy_placeholders_only",
+			template: "Released in {version}, unknown {unknown}",
+			expected: "Released in 1.2.3, unknown {unknown}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.renderComment(p.parseConfig(nil), tt.template, releaseCtx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestRenderCommentDoesNotEvaluateValues tests that context values containing template syntax stay literal.
+func TestRenderCommentDoesNotEvaluateValues(t *testing.T) {
+	p := &JiraPlugin{}
+	releaseCtx := plugin.ReleaseContext{Version: "1.0.0", RepositoryName: "{{.Version}}"}
+
+	got, err := p.renderComment(p.parseConfig(nil), "{{.Repository}} / {repository}", releaseCtx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "{{.Version}} / {{.Version}}" {
+		t.Errorf("expected values to be inserted verbatim, got %q", got)
+	}
+}
+
+func TestRenderCommentTemplateErrors(t *testing.T) {
+	p := &JiraPlugin{}
+	for _, text := range []string{"{{.Version", "{{.Unknown}}"} {
+		if _, err := p.renderComment(p.parseConfig(nil), text, plugin.ReleaseContext{}); err == nil {
+			t.Errorf("expected error rendering %q", text)
+		}
+	}
+}
+
+func TestValidateCommentTemplateSyntax(t *testing.T) {
+	p := &JiraPlugin{}
+	tests := []struct {
+		name  string
+		field string
+		value string
+		valid bool
+	}{
+		{"valid_go_template", "comment_template", "Released {{.Version}}", true},
+		{"legacy_placeholders", "comment_template", "Released {version}", true},
+		{"unclosed_action", "comment_template", "Released {{.Version", false},
+		{"unclosed_range", "released_comment_template", "{{range .Issues}}{{.}}", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"base_url":    "https://company.atlassian.net",
+				"project_key": "PROJ",
+				"username":    "user@example.com",
+				"token":       "token",
+				tt.field:      tt.value,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.valid {
+				t.Fatalf("expected valid=%v, got %v (%v)", tt.valid, resp.Valid, resp.Errors)
+			}
+			if !tt.valid && (resp.Errors[0].Field != tt.field || resp.Errors[0].Code != "format") {
+				t.Errorf("expected a format error on %s, got %v", tt.field, resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/felixgeelhaar/jirasdk/transport"
+)
+
+// errNotJiraEndpoint is returned when the server answers with something other than JSON,
+// which usually means base_url points at a login page or web UI instead of the REST API.
+var errNotJiraEndpoint = errors.New("base_url does not appear to be a Jira REST endpoint")
+
+// errJiraMaintenance is returned when Jira answers 503 with an HTML page, which Atlassian serves
+// during maintenance. 503 responses to idempotent requests are retried before the middleware
+// sees them, so for those this is only reported once the retries are exhausted.
+var errJiraMaintenance = errors.New("Jira is in maintenance; retry the release once the site is available again")
+
+// errCredentialsExpired is returned when Jira starts answering 401 after earlier requests
+// with the same credentials succeeded, which happens when a token expires during a run.
+var errCredentialsExpired = errors.New("Jira credentials expired mid-run")
+
+// credentialExpiry detects credentials that stop working partway through a run.
+type credentialExpiry struct {
+	mu        sync.Mutex
+	succeeded bool
+	expired   bool
+}
+
+// middleware turns a 401 that follows a successful request into errCredentialsExpired, and
+// fails later requests fast instead of sending them with the expired credentials.
+func (c *credentialExpiry) middleware() transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			c.mu.Lock()
+			expired := c.expired
+			c.mu.Unlock()
+			if expired {
+				return nil, errCredentialsExpired
+			}
+
+			resp, err := next(ctx, req)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			switch {
+			case resp.StatusCode == http.StatusUnauthorized && c.succeeded:
+				_ = resp.Body.Close()
+				c.expired = true
+				return nil, fmt.Errorf("%w (HTTP %d)", errCredentialsExpired, resp.StatusCode)
+			case resp.StatusCode >= 200 && resp.StatusCode < 300:
+				c.succeeded = true
+			}
+			return resp, nil
+		}
+	}
+}
+
+// releaseHeader names the release that made a change, so Jira's audit logs can attribute it.
+const releaseHeader = "X-Relicta-Release"
+
+// releaseHeaderMiddleware sets releaseHeader to the release version on requests that change
+// Jira data. Reads are sent unchanged.
+func releaseHeaderMiddleware(version string) transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if version != "" && !strings.ContainsAny(version, "\r\n") && isMutatingMethod(req.Method) {
+				req.Header.Set(releaseHeader, version)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// requestLimiter spaces out requests that change Jira data so a release touching many
+// issues stays under Jira Cloud's per-minute quota. A nil limiter does not throttle.
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRequestLimiter returns a limiter allowing requestsPerSecond mutating requests, or nil
+// when requestsPerSecond is not positive.
+func newRequestLimiter(requestsPerSecond float64) *requestLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &requestLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// wait blocks until the next request slot, or until ctx is done.
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// middleware throttles requests that change Jira data; reads are sent unchanged. The
+// limiter is shared by every client of a run, so the rate holds across Jira instances.
+func (l *requestLimiter) middleware() transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if l != nil && isMutatingMethod(req.Method) {
+				if err := l.wait(ctx); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// isMutatingMethod reports whether an HTTP method changes data.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// contentTypeMiddleware rejects non-JSON responses with a clear configuration error
+// instead of letting the SDK fail while decoding them.
+func contentTypeMiddleware() transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+
+			contentType := resp.Header.Get("Content-Type")
+			if contentType == "" || isJSONContentType(contentType) {
+				return resp, nil
+			}
+
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusServiceUnavailable && isHTMLContentType(contentType) {
+				return nil, fmt.Errorf("%w (HTTP %d with a maintenance page)", errJiraMaintenance, resp.StatusCode)
+			}
+			return nil, fmt.Errorf("%w (got %s response with HTTP %d)", errNotJiraEndpoint, contentType, resp.StatusCode)
+		}
+	}
+}
+
+// isJSONContentType reports whether a Content-Type header denotes a JSON payload.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isHTMLContentType reports whether a Content-Type header denotes an HTML page.
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/html"
+}
+
+// timeNow returns the local time. It is a variable so tests can simulate clock skew.
+var timeNow = time.Now
+
+// serverClock records the Jira server's clock offset from the Date header of the first response.
+type serverClock struct {
+	mu    sync.Mutex
+	known bool
+	skew  time.Duration
+}
+
+// middleware captures the server's Date header once per client.
+func (c *serverClock) middleware() transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if !c.known {
+				if serverDate, parseErr := http.ParseTime(resp.Header.Get("Date")); parseErr == nil {
+					c.skew = serverDate.Sub(timeNow())
+					c.known = true
+				}
+			}
+			return resp, nil
+		}
+	}
+}
+
+// offset returns how far the server clock is ahead of the local clock (negative when behind).
+func (c *serverClock) offset() (time.Duration, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skew, c.known
+}
+
+// dialContext opens connections to Jira. It is a variable so tests can inject network failures.
+var dialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+
+const (
+	// defaultTimeoutSeconds is the per-request timeout used when timeout_seconds is unset.
+	defaultTimeoutSeconds = 30
+	// maxTimeoutSeconds is the largest timeout_seconds accepted by validation.
+	maxTimeoutSeconds = 300
+)
+
+// newHTTPClient returns the HTTP client used for Jira requests, dialing through dialContext.
+// certs, when present, are presented to servers that request a client certificate. Requests
+// go through proxy when set, otherwise through the HTTPS_PROXY/HTTP_PROXY environment proxy.
+func newHTTPClient(timeout time.Duration, certs []tls.Certificate, proxy *url.URL) *http.Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		base.Proxy = http.ProxyURL(proxy)
+	}
+	base.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialContext(ctx, network, addr)
+	}
+	if len(certs) > 0 {
+		base.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12, Certificates: certs}
+	}
+	return &http.Client{Timeout: timeout, Transport: base}
+}
+
+// loadClientCertificate loads the mTLS client certificate configured by client_cert_file and
+// client_key_file. It returns nil when neither is set.
+func loadClientCertificate(cfg *Config) ([]tls.Certificate, error) {
+	if cfg.ClientCertFile == "" && cfg.ClientKeyFile == "" {
+		return nil, nil
+	}
+	if cfg.ClientCertFile == "" || cfg.ClientKeyFile == "" {
+		return nil, errors.New("client_cert_file and client_key_file must be set together")
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	return []tls.Certificate{cert}, nil
+}
+
+// proxyURL parses the proxy configured by proxy_url. It returns nil when none is set.
+func proxyURL(cfg *Config) (*url.URL, error) {
+	if cfg.ProxyURL == "" {
+		return nil, nil
+	}
+	proxy, err := url.Parse(cfg.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy_url: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy_url must use http, https or socks5, got %q", proxy.Scheme)
+	}
+	if proxy.Hostname() == "" {
+		return nil, errors.New("proxy_url must include a host")
+	}
+	return proxy, nil
+}
+
+// retryBackoff returns the delay before a retry attempt. It is a variable so tests can skip the wait.
+var retryBackoff = func(attempt int) time.Duration {
+	delay := 100 * time.Millisecond << attempt
+	if delay > 5*time.Second || delay <= 0 {
+		return 5 * time.Second
+	}
+	return delay
+}
+
+// retryMiddleware retries requests that fail with a transient network error or a retryable
+// server status, up to maxRetries times. It replaces the SDK's retry, which retries every
+// error indiscriminately and re-sends already consumed request bodies. Rate limiting (429)
+// is still handled by the SDK, which honors Retry-After.
+//
+// Only idempotent requests are retried after they may have reached Jira. A POST that timed
+// out or got a 5xx may already have added its comment or created its version, so it is only
+// retried when the connection could not be established.
+func retryMiddleware(maxRetries int) transport.Middleware {
+	return func(next transport.RoundTripFunc) transport.RoundTripFunc {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			for attempt := 0; ; attempt++ {
+				if attempt > 0 && req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(ctx)
+					req.Body = body
+				}
+
+				resp, err := next(ctx, req)
+				idempotent := isIdempotentMethod(req.Method)
+				var retry bool
+				if err != nil {
+					retry = ctx.Err() == nil && isRetryableNetworkError(err) && (idempotent || isDialError(err))
+				} else {
+					retry = idempotent && isRetryableStatus(resp.StatusCode)
+				}
+				if !retry || attempt >= maxRetries {
+					return resp, err
+				}
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+
+				select {
+				case <-time.After(retryBackoff(attempt)):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+// isIdempotentMethod reports whether repeating a request with the method has the same effect
+// as sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDialError reports whether err happened while connecting, before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isRetryableStatus reports whether a response status denotes a transient server failure.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableNetworkError reports whether err is a transient network failure: a timeout,
+// a reset or aborted connection, an unexpected EOF, or a temporary DNS failure.
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
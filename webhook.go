@@ -0,0 +1,35 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// webhookSignaturePrefix precedes the hex digest in Jira's X-Hub-Signature header.
+const webhookSignaturePrefix = "sha256="
+
+// errInvalidWebhookSignature is returned when a webhook body does not match its signature.
+var errInvalidWebhookSignature = errors.New("invalid Jira webhook signature")
+
+// verifyJiraWebhook checks a Jira webhook body against its HMAC-SHA256 signature, given
+// as a hex digest with or without the "sha256=" prefix. The comparison is constant-time.
+func verifyJiraWebhook(secret string, body []byte, signature string) error {
+	if secret == "" {
+		return errors.New("webhook_secret is not configured")
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), webhookSignaturePrefix))
+	if err != nil {
+		return errInvalidWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errInvalidWebhookSignature
+	}
+	return nil
+}
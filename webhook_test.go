@@ -0,0 +1,52 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// sign returns the hex HMAC-SHA256 of body, as Jira computes it.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestVerifyJiraWebhook tests webhook signature verification.
+func TestVerifyJiraWebhook(t *testing.T) {
+	secret := "s3cret"
+	body := []byte(`{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`)
+	signature := sign(secret, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		wantErr   bool
+	}{
+		{"valid", secret, body, signature, false},
+		{"valid with prefix", secret, body, "sha256=" + signature, false},
+		{"tampered body", secret, []byte(`{"webhookEvent":"jira:issue_deleted","issue":{"key":"PROJ-1"}}`), signature, true},
+		{"tampered signature", secret, body, sign(secret, []byte("other")), true},
+		{"wrong secret", "other", body, signature, true},
+		{"not hex", secret, body, "sha256=not-hex", true},
+		{"empty signature", secret, body, "", true},
+		{"no secret", "", body, signature, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyJiraWebhook(tt.secret, tt.body, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyJiraWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tt.secret != "" && !errors.Is(err, errInvalidWebhookSignature) {
+				t.Errorf("expected errInvalidWebhookSignature, got %v", err)
+			}
+		})
+	}
+}